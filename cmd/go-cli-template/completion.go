@@ -1,15 +1,19 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/adapters/shell"
 )
 
 func newCompletionCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "completion [bash|zsh|fish|powershell]",
+		Use:   "completion [bash|zsh|fish|powershell|nu|xonsh|elvish|tcsh]",
 		Short: "Generate shell completion scripts",
 		Long:  completionHelp(),
 		Args:  cobra.MaximumNArgs(1),
@@ -22,21 +26,35 @@ func newCompletionCmd() *cobra.Command {
 
 func runCompletion(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("shell is required (bash, zsh, fish, powershell)")
+		return fmt.Errorf("shell is required (bash, zsh, fish, powershell, nu, xonsh, elvish, tcsh)")
+	}
+	err := writeCompletion(cmd.Root(), cmd.OutOrStdout(), shell.New(strings.ToLower(strings.TrimSpace(args[0]))))
+	if errors.Is(err, shell.ErrNoCompletion) {
+		return fmt.Errorf("unsupported shell %q", args[0])
 	}
-	shell := strings.ToLower(strings.TrimSpace(args[0]))
-	switch shell {
+	return err
+}
+
+// writeCompletion writes the completion script for root in the adapter's
+// shell, using cobra's generators where it has one. Returns
+// shell.ErrNoCompletion for shells without programmable completion.
+func writeCompletion(root *cobra.Command, w io.Writer, adapter *shell.Adapter) error {
+	switch adapter.Shell() {
 	case "bash":
-		return cmd.Root().GenBashCompletion(cmd.OutOrStdout())
+		return root.GenBashCompletion(w)
 	case "zsh":
-		return cmd.Root().GenZshCompletion(cmd.OutOrStdout())
+		return root.GenZshCompletion(w)
 	case "fish":
-		return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true)
-	case "powershell":
-		return cmd.Root().GenPowerShellCompletion(cmd.OutOrStdout())
-	default:
-		return fmt.Errorf("unsupported shell %q", shell)
+		return root.GenFishCompletion(w, true)
+	case "pwsh":
+		return root.GenPowerShellCompletion(w)
+	}
+	script, err := adapter.CompletionScript(root.Name())
+	if err != nil {
+		return err
 	}
+	_, err = io.WriteString(w, script)
+	return err
 }
 
 func completionHelp() string {
@@ -46,5 +64,9 @@ func completionHelp() string {
 		"  go-cli-template completion zsh > ~/.zsh/completion/_go-cli-template",
 		"  go-cli-template completion fish > ~/.config/fish/completions/go-cli-template.fish",
 		"  go-cli-template completion powershell > go-cli-template.ps1",
+		"  go-cli-template completion nu | save -f ~/.config/nushell/go-cli-template.nu",
+		"  go-cli-template completion xonsh > ~/.config/xonsh/rc.d/go-cli-template.xsh",
+		"  go-cli-template completion elvish > ~/.config/elvish/lib/go-cli-template.elv",
+		"  go-cli-template completion tcsh > ~/.go-cli-template.tcsh",
 	}, "\n")
 }
@@ -1,14 +1,16 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
-	"github.com/go-cli-template/internal/adapters/editor"
+	"github.com/go-cli-template/internal/adapters/tty"
 	"github.com/go-cli-template/internal/config"
 	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/ui"
 	"github.com/go-cli-template/internal/utils"
 )
 
@@ -22,6 +24,12 @@ func newConfigCmd() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(newConfigInitCmd())
+	cmd.AddCommand(newConfigListCmd())
+	cmd.AddCommand(newConfigProfilesCmd())
+	cmd.AddCommand(newConfigConvertCmd())
+	cmd.AddCommand(newConfigEditCmd())
+	cmd.AddCommand(newConfigMigrateCmd())
+	cmd.AddCommand(newConfigDiffCmd())
 	return cmd
 }
 
@@ -30,46 +38,99 @@ func runConfig(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
-	manager := config.NewManager(cwd)
-	cfg, err := manager.Load()
-	if err != nil {
-		return err
-	}
-
-	path, err := resolveConfigPath(cwd)
+	manager := newConfigManager(cmd, cwd)
+	path, err := resolveConfigPath(manager)
 	if err != nil {
 		return err
 	}
+	return openConfigInEditor(cmd, manager, path)
+}
 
-	if !pathExists(path) {
+// openConfigInEditor opens path in the configured editor, writing the
+// default template first when the file does not exist yet.
+func openConfigInEditor(cmd *cobra.Command, manager *config.ManagerImpl, path string) error {
+	var cfg domain.Config
+	if pathExists(path) {
+		cfg = loadConfigOrDefault(cmd, manager)
+	} else {
 		cfg = domain.DefaultConfig()
-		content := renderConfigTemplate(cfg)
+		content, err := renderConfigFile(path, cfg)
+		if err != nil {
+			return err
+		}
 		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 			return err
 		}
-		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
 			return err
 		}
 	}
 
-	editorAdapter := editor.New(cfg.Editor)
-	if err := editorAdapter.Open(path); err != nil {
+	if err := editConfigFile(cmd, cfg, path); err != nil {
 		return err
 	}
 	cmd.Printf("Opened config %s\n", path)
 	return nil
 }
 
-func resolveConfigPath(cwd string) (string, error) {
-	localPath := utils.ConfigPathLocal(cwd)
-	if pathExists(localPath) {
-		return localPath, nil
+// editConfigFile opens path in the editor and checks it once the editor
+// exits. Problems are reported, and in a terminal the user is offered to
+// reopen the file until it is valid.
+func editConfigFile(cmd *cobra.Command, cfg domain.Config, path string) error {
+	editorAdapter := newEditor(cfg)
+	for {
+		if err := editorAdapter.Open(path); err != nil {
+			return err
+		}
+		problems, err := config.ValidateFile(path)
+		if err == nil && len(problems) == 0 {
+			return nil
+		}
+		for _, problem := range problems {
+			printWarning(cmd, "%s", problem)
+		}
+		if !isInteractive() {
+			return err
+		}
+		if err != nil {
+			cmd.PrintErrf("Error: %v\n", err)
+		}
+		reopen, promptErr := ui.PromptConfirmation(
+			"Config has problems",
+			"Reopen the config in your editor to fix them?",
+			ui.ThemeFromConfig(cfg),
+		)
+		if promptErr == nil && reopen {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("config %s is still invalid", path)
+		}
+		return nil
+	}
+}
+
+// isInteractive reports whether stdin and stdout are both terminals, so
+// the user can be prompted. Tests replace it to avoid prompting.
+var isInteractive = func() bool {
+	return tty.IsTerminal(os.Stdin.Fd()) && tty.IsTerminal(os.Stdout.Fd())
+}
+
+// resolveConfigPath returns the --config path when set, otherwise the
+// innermost local config, falling back to the global config path.
+func resolveConfigPath(manager *config.ManagerImpl) (string, error) {
+	if path := manager.ConfigPath(); path != "" {
+		return path, nil
+	}
+	localPaths, err := manager.LocalPaths()
+	if err != nil {
+		return "", err
 	}
-	globalPath := utils.ConfigPathGlobal()
-	if pathExists(globalPath) {
-		return globalPath, nil
+	if len(localPaths) > 0 {
+		return localPaths[len(localPaths)-1], nil
 	}
-	return globalPath, nil
+	path, _, err := config.FindConfigFile(utils.ConfigPathGlobal())
+	return path, err
 }
 
 func pathExists(path string) bool {
@@ -79,3 +140,13 @@ func pathExists(path string) bool {
 	}
 	return !info.IsDir()
 }
+
+// unusedPath returns base+ext, or base-1+ext, base-2+ext and so on when
+// that file already exists.
+func unusedPath(base, ext string) string {
+	path := base + ext
+	for i := 1; pathExists(path); i++ {
+		path = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	return path
+}
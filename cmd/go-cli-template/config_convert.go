@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/config"
+)
+
+type configConvertOptions struct {
+	to    string
+	force bool
+	keep  bool
+}
+
+func newConfigConvertCmd() *cobra.Command {
+	opts := &configConvertOptions{}
+	cmd := &cobra.Command{
+		Use:   "convert [path]",
+		Short: "Convert a config file to another format",
+		Long:  configConvertHelp(),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigConvert(cmd, opts, args)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.to, "to", "t", "", "target format (toml, yaml, json)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite an existing target file")
+	cmd.Flags().BoolVarP(&opts.keep, "keep", "k", false, "keep the original file instead of renaming it to .bak")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func runConfigConvert(cmd *cobra.Command, opts *configConvertOptions, args []string) error {
+	ext, err := config.FormatExt(opts.to)
+	if err != nil {
+		return err
+	}
+
+	source := ""
+	if len(args) > 0 {
+		source = args[0]
+	} else {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		source, err = resolveConfigPath(newConfigManager(cmd, cwd))
+		if err != nil {
+			return err
+		}
+	}
+	if !pathExists(source) {
+		return fmt.Errorf("config file %s not found", source)
+	}
+
+	target := strings.TrimSuffix(source, filepath.Ext(source)) + ext
+	if target == source {
+		return fmt.Errorf("%s is already in %s format", source, strings.TrimPrefix(ext, "."))
+	}
+	if pathExists(target) && !opts.force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", target)
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	converted, err := config.Convert(data, source, target)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	if err := writeConverted(target, converted); err != nil {
+		return err
+	}
+
+	cmd.Printf("Wrote %s\n", target)
+	if !opts.keep {
+		backup := unusedPath(source, ".bak")
+		if err := os.Rename(source, backup); err != nil {
+			return err
+		}
+		cmd.Printf("Moved %s to %s\n", source, backup)
+	}
+	cmd.Println("Note: comments are not carried over to the converted file.")
+	return nil
+}
+
+// writeConverted writes data to a temporary file next to target and only
+// moves it into place once it loads as a config file.
+func writeConverted(target string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(target), ".convert-*"+filepath.Ext(target))
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	defer os.Remove(tmp)
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if _, err := config.ValidateFile(tmp); err != nil {
+		return fmt.Errorf("converted config does not load, %s left unchanged: %w", target, err)
+	}
+	if err := os.Chmod(tmp, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+func configConvertHelp() string {
+	return strings.Join([]string{
+		"Convert a config file between TOML, YAML and JSON.",
+		"",
+		"Without a path, the config that `config` would open is converted.",
+		"The converted file is checked to load before anything is renamed. The",
+		"original is then renamed to <file>.bak (or <file>-1.bak and so on when",
+		"that exists) so the converted file takes effect.",
+		"",
+		"Examples:",
+		"  go-cli-template config convert --to yaml",
+		"  go-cli-template config convert --to json ./.go-cli-template/config.toml",
+	}, "\n")
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/config"
+	"github.com/go-cli-template/internal/ui"
+)
+
+type configDiffOptions struct {
+	format  string
+	changed bool
+}
+
+func newConfigDiffCmd() *cobra.Command {
+	opts := &configDiffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare config layers with the defaults",
+		Long:  configDiffHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigDiff(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "text", "output format (text, json)")
+	cmd.Flags().BoolVar(&opts.changed, "changed", false, "only show keys whose effective value differs from the default")
+	return cmd
+}
+
+func runConfigDiff(cmd *cobra.Command, opts *configDiffOptions) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	manager := newConfigManager(cmd, cwd)
+	cfg := loadConfigOrDefault(cmd, manager)
+	diffs, err := manager.Diff()
+	if err != nil {
+		return err
+	}
+
+	if opts.changed {
+		filtered := diffs[:0]
+		for _, diff := range diffs {
+			if diff.Changed() {
+				filtered = append(filtered, diff)
+			}
+		}
+		diffs = filtered
+	}
+
+	switch opts.format {
+	case "json":
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(data))
+		return nil
+	case "text", "":
+		cmd.Print(renderConfigDiff(diffs, ui.ThemeFromConfig(cfg)))
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q (use text or json)", opts.format)
+	}
+}
+
+// renderConfigDiff renders diffs as an aligned table. Unset layers are
+// muted and values that differ from the default are highlighted.
+func renderConfigDiff(diffs []config.KeyDiff, theme ui.Theme) string {
+	header := lipgloss.NewStyle().Foreground(theme.Headings).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+	unset := lipgloss.NewStyle().Foreground(theme.Muted)
+	plain := lipgloss.NewStyle().Foreground(theme.Text)
+	changed := lipgloss.NewStyle().Foreground(theme.TextHighlight).Bold(true)
+
+	rows := [][]string{{"KEY", "DEFAULT", "GLOBAL", "LOCAL", "EFFECTIVE"}}
+	for _, diff := range diffs {
+		rows = append(rows, []string{
+			diff.Key,
+			formatDiffValue(diff.Default),
+			formatDiffValue(diff.Global),
+			formatDiffValue(diff.Local),
+			formatDiffValue(diff.Effective),
+		})
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var builder strings.Builder
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			style := plain
+			switch {
+			case r == 0:
+				style = header
+			case i == 0:
+				style = keyStyle
+			case cell == "-":
+				style = unset
+			case i > 1 && !isDefaultValue(diffs[r-1], i):
+				style = changed
+			}
+			cells[i] = style.Render(cell) + strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+		}
+		builder.WriteString(strings.TrimRight(strings.Join(cells, "  "), " "))
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// isDefaultValue reports whether the value in column matches the default.
+func isDefaultValue(diff config.KeyDiff, column int) bool {
+	values := []any{diff.Key, diff.Default, diff.Global, diff.Local, diff.Effective}
+	return fmt.Sprint(values[column]) == fmt.Sprint(diff.Default)
+}
+
+func formatDiffValue(value any) string {
+	if value == nil {
+		return "-"
+	}
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case bool, int:
+		return fmt.Sprint(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func configDiffHelp() string {
+	return strings.Join([]string{
+		"Show each config key's default, global, local and effective value.",
+		"",
+		"Global covers system and user config; local covers project configs.",
+		"Effective is the final value after profiles are applied. A dash means",
+		"the layer does not set the key.",
+		"",
+		"Examples:",
+		"  go-cli-template config diff",
+		"  go-cli-template config diff --changed",
+		"  go-cli-template config diff --format json",
+	}, "\n")
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/config"
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/ui"
+	"github.com/go-cli-template/internal/utils"
+)
+
+type configEditOptions struct {
+	tui    bool
+	local  bool
+	global bool
+}
+
+func newConfigEditCmd() *cobra.Command {
+	opts := &configEditOptions{}
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit configuration in an editor or interactive form",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(cmd, opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.tui, "tui", "t", false, "edit in an interactive form instead of a text editor")
+	cmd.Flags().BoolVarP(&opts.local, "local", "l", false, "edit the project config")
+	cmd.Flags().BoolVarP(&opts.global, "global", "g", false, "edit the global config")
+	cmd.MarkFlagsMutuallyExclusive("local", "global")
+	return cmd
+}
+
+func runConfigEdit(cmd *cobra.Command, opts *configEditOptions) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	manager := newConfigManager(cmd, cwd)
+	path, err := configEditPath(cmd, opts, manager, cwd)
+	if err != nil {
+		return err
+	}
+	if !opts.tui {
+		return openConfigInEditor(cmd, manager, path)
+	}
+
+	// Seed the form from the layer being edited, so values set by
+	// higher-precedence layers are not copied into it
+	cfg, err := manager.LoadThrough(path)
+	if err != nil {
+		return err
+	}
+	printConfigWarnings(cmd, manager)
+	edited := cfg
+	save := true
+	form := ui.NewConfigForm(&edited, &save, ui.ThemeFromConfig(cfg))
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			cmd.Println("Edit cancelled")
+			return nil
+		}
+		return err
+	}
+	if !save {
+		cmd.Println("Changes discarded")
+		return nil
+	}
+
+	changes := config.ChangedValues(cfg, edited)
+	if len(changes) == 0 {
+		cmd.Println("No changes")
+		return nil
+	}
+	count := len(changes)
+	if !pathExists(path) {
+		changes["version"] = domain.ConfigVersion
+	}
+	if err := config.SetValues(path, changes); err != nil {
+		return err
+	}
+	cmd.Printf("Saved %d change(s) to %s\n", count, path)
+	warnOverriddenChanges(cmd, manager, changes, edited)
+	return nil
+}
+
+// warnOverriddenChanges warns about saved keys that a higher-precedence
+// layer sets to something else, since the edit will not take effect.
+func warnOverriddenChanges(cmd *cobra.Command, manager *config.ManagerImpl, changes map[string]any, edited domain.Config) {
+	merged, err := manager.Load()
+	if err != nil {
+		return
+	}
+	overridden := config.ChangedValues(edited, merged)
+	var keys []string
+	for key := range changes {
+		if _, ok := overridden[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		printWarning(cmd, "%s is overridden by a higher-precedence config layer", key)
+	}
+}
+
+// configEditPath picks the layer to edit: --config, the innermost project
+// config (or the repository root) for --local, the global config for
+// --global, and otherwise the file `config` would open.
+func configEditPath(cmd *cobra.Command, opts *configEditOptions, manager *config.ManagerImpl, cwd string) (string, error) {
+	if path := configFlag(cmd); path != "" {
+		if opts.local || opts.global {
+			return "", fmt.Errorf("--config cannot be combined with --local or --global")
+		}
+		return path, nil
+	}
+	if opts.global {
+		path, _, err := config.FindConfigFile(utils.ConfigPathGlobal())
+		return path, err
+	}
+	if opts.local {
+		localPaths, err := manager.LocalPaths()
+		if err != nil {
+			return "", err
+		}
+		if len(localPaths) > 0 {
+			return localPaths[len(localPaths)-1], nil
+		}
+		return utils.ConfigPathLocal(utils.ProjectRoot(cwd)), nil
+	}
+	return resolveConfigPath(manager)
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/go-cli-template/internal/adapters/editor"
 	"github.com/go-cli-template/internal/config"
 	"github.com/go-cli-template/internal/domain"
 	"github.com/go-cli-template/internal/utils"
@@ -17,6 +16,8 @@ import (
 type configInitOptions struct {
 	force        bool
 	openInEditor bool
+	local        bool
+	global       bool
 }
 
 func newConfigInitCmd() *cobra.Command {
@@ -30,6 +31,9 @@ func newConfigInitCmd() *cobra.Command {
 	}
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite existing config")
 	cmd.Flags().BoolVarP(&opts.openInEditor, "editor", "e", false, "open config in editor after creation")
+	cmd.Flags().BoolVarP(&opts.local, "local", "l", false, "create a project config at the repository root")
+	cmd.Flags().BoolVarP(&opts.global, "global", "g", false, "create the global config (default)")
+	cmd.MarkFlagsMutuallyExclusive("local", "global")
 	return cmd
 }
 
@@ -38,45 +42,90 @@ func runConfigInit(cmd *cobra.Command, opts *configInitOptions) error {
 	if err != nil {
 		return err
 	}
-	manager := config.NewManager(cwd)
-	exists, err := manager.Exists()
+	path, err := configInitPath(cmd, opts, cwd)
 	if err != nil {
 		return err
 	}
-	if exists && !opts.force {
-		return fmt.Errorf("config already exists at %s (use --force to overwrite)", utils.ConfigPathGlobal())
+	if pathExists(path) && !opts.force {
+		return fmt.Errorf("config already exists at %s (use --force to overwrite)", path)
 	}
 	cfg := domain.DefaultConfig()
-	path := utils.ConfigPathGlobal()
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	content := renderConfigTemplate(cfg)
-	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+	content, err := renderConfigFile(path, cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
 		return err
 	}
 	if opts.openInEditor {
-		editorAdapter := editor.New(cfg.Editor)
-		if err := editorAdapter.Open(path); err != nil {
+		if err := editConfigFile(cmd, cfg, path); err != nil {
 			return err
 		}
 	}
-	cmd.Printf("Wrote config to %s\n", utils.ConfigPathGlobal())
+	cmd.Printf("Wrote config to %s\n", path)
 	return nil
 }
 
+// configInitPath picks the file to create: --config, the project root for
+// --local, or the global config.
+func configInitPath(cmd *cobra.Command, opts *configInitOptions, cwd string) (string, error) {
+	if path := configFlag(cmd); path != "" {
+		if opts.local || opts.global {
+			return "", fmt.Errorf("--config cannot be combined with --local or --global")
+		}
+		return path, nil
+	}
+	path := utils.ConfigPathGlobal()
+	if opts.local {
+		path = utils.ConfigPathLocal(utils.ProjectRoot(cwd))
+	}
+	// Target an existing YAML or JSON config instead of shadowing it with TOML
+	path, _, err := config.FindConfigFile(path)
+	return path, err
+}
+
+// renderConfigFile renders the default config for path's format. TOML files
+// get the commented template; other formats are encoded from cfg.
+func renderConfigFile(path string, cfg domain.Config) ([]byte, error) {
+	if config.CodecFor(path) == config.CodecFor(".toml") {
+		return []byte(renderConfigTemplate(cfg)), nil
+	}
+	return config.CodecFor(path).Marshal(cfg)
+}
+
 func renderConfigTemplate(cfg domain.Config) string {
 	var builder strings.Builder
 	builder.WriteString("# Generic CLI Tool Configuration\n\n")
+	builder.WriteString("# Schema version; upgrade older files with `config migrate`\n")
+	builder.WriteString(fmt.Sprintf("version = %d\n\n", cfg.Version))
 	builder.WriteString("# General\n")
 	builder.WriteString(fmt.Sprintf("# editor = %q\n", cfg.Editor))
+	builder.WriteString("# Command used to open files at a position; {file}, {line} and {col} are substituted\n")
+	builder.WriteString("# editor_line_template = \"hx {file}:{line}:{col}\"\n")
+	builder.WriteString("# editor_layout options: tabs, vsplit, split (how vim opens several files)\n")
+	builder.WriteString(fmt.Sprintf("# editor_layout = %q\n", cfg.EditorLayout))
+	builder.WriteString("# Add --wait (or the editor's equivalent) to GUI editors so edits finish before continuing\n")
+	builder.WriteString(fmt.Sprintf("# editor_wait = %t\n", cfg.EditorWait))
+	builder.WriteString("# Compare files with a diff tool instead of the editor's diff mode\n")
+	builder.WriteString("# diff_tool = \"meld\"\n")
 	builder.WriteString("\n# CLI behavior\n")
 	builder.WriteString(fmt.Sprintf("# interactive_default = %t\n", cfg.InteractiveDefault))
+	builder.WriteString("# local_config_boundary options: git (stop at git root or $HOME), home, root, none (current directory only)\n")
+	builder.WriteString(fmt.Sprintf("# local_config_boundary = %q\n", cfg.LocalConfigBoundary))
 	builder.WriteString("\n# UI\n")
 	builder.WriteString("# list_spacing options: compact (title only), tight (title + description, no margin), space (default, with spacing)\n")
 	builder.WriteString(fmt.Sprintf("# list_spacing = %q\n", cfg.ListSpacing))
+	builder.WriteString("# keymap options: default, vim, emacs\n")
+	builder.WriteString(fmt.Sprintf("# keymap = %q\n", cfg.Keymap))
 	builder.WriteString("\n# Colors\n")
+	builder.WriteString("# theme sets every color at once; run `theme list` to see the options.\n")
+	builder.WriteString(fmt.Sprintf("# theme = %q\n", cfg.Theme))
 	builder.WriteString("# Colors support named, numeric, or hex values (ex: 7, 13, \"#ff8800\").\n")
+	builder.WriteString("# Use { light = \"..\", dark = \"..\" } (or \"light|dark\") to follow the terminal background.\n")
+	builder.WriteString("# Individual colors override the theme.\n")
 	builder.WriteString(fmt.Sprintf("# headings = %q\n", cfg.Headings))
 	builder.WriteString(fmt.Sprintf("# primary = %q\n", cfg.Primary))
 	builder.WriteString(fmt.Sprintf("# secondary = %q\n", cfg.Secondary))
@@ -87,5 +136,17 @@ func renderConfigTemplate(cfg domain.Config) string {
 	builder.WriteString(fmt.Sprintf("# flags = %q\n", cfg.Flags))
 	builder.WriteString(fmt.Sprintf("# muted = %q\n", cfg.Muted))
 	builder.WriteString(fmt.Sprintf("# border = %q\n", cfg.Border))
+	builder.WriteString("# Status colors and list highlights\n")
+	builder.WriteString(fmt.Sprintf("# success = %q\n", cfg.Success))
+	builder.WriteString(fmt.Sprintf("# warning = %q\n", cfg.Warning))
+	builder.WriteString(fmt.Sprintf("# error = %q\n", cfg.Error))
+	builder.WriteString(fmt.Sprintf("# info = %q\n", cfg.Info))
+	builder.WriteString("# selection_background is unset by default (no background)\n")
+	builder.WriteString("# selection_background = \"#313244\"\n")
+	builder.WriteString(fmt.Sprintf("# filter_match = %q\n", cfg.FilterMatch))
+	builder.WriteString(fmt.Sprintf("\n# Key bindings override the keymap per action: %s.\n", strings.Join(domain.KeyActions, ", ")))
+	builder.WriteString("# An empty list unbinds the action.\n")
+	builder.WriteString("# [keys]\n")
+	builder.WriteString("# delete = [\"d\", \"delete\"]\n")
 	return builder.String()
 }
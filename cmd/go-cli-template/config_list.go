@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+)
+
+func newConfigListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print the effective configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(cmd)
+		},
+	}
+	return cmd
+}
+
+func newConfigProfilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "List available config profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigProfiles(cmd)
+		},
+	}
+	return cmd
+}
+
+func runConfigList(cmd *cobra.Command) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	manager := newConfigManager(cmd, cwd)
+	cfg, err := manager.Load()
+	if err != nil {
+		return err
+	}
+	printConfigWarnings(cmd, manager)
+	if profile := manager.ActiveProfile(); profile != "" {
+		cmd.Printf("# profile: %s\n", profile)
+	}
+	data, err := toml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	cmd.Print(string(data))
+	return nil
+}
+
+func runConfigProfiles(cmd *cobra.Command) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	manager := newConfigManager(cmd, cwd)
+	names, err := manager.Profiles()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		cmd.Println("No profiles defined")
+		return nil
+	}
+	active := manager.ActiveProfile()
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		cmd.Printf("%s %s\n", marker, name)
+	}
+	return nil
+}
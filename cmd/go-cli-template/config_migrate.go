@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/config"
+)
+
+type configMigrateOptions struct {
+	dryRun bool
+}
+
+func newConfigMigrateCmd() *cobra.Command {
+	opts := &configMigrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate [path...]",
+		Short: "Upgrade config files to the current schema version",
+		Long:  configMigrateHelp(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigMigrate(cmd, opts, args)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "show the changes without writing files")
+	return cmd
+}
+
+func runConfigMigrate(cmd *cobra.Command, opts *configMigrateOptions, args []string) error {
+	paths := args
+	if len(paths) == 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		// Theme and included files are not config layers, and layers without
+		// a version are treated as current, so none of them are touched
+		layers, err := newConfigManager(cmd, cwd).LayerPaths()
+		if err != nil {
+			return err
+		}
+		for _, path := range layers {
+			if !pathExists(path) {
+				continue
+			}
+			versioned, err := config.Versioned(path)
+			if err != nil {
+				return err
+			}
+			if !versioned {
+				cmd.Printf("%s has no version; skipped (pass its path to add one)\n", path)
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		cmd.Println("No config files to migrate")
+		return nil
+	}
+
+	migrated := 0
+	for _, path := range paths {
+		result, err := config.MigrateFile(path, opts.dryRun)
+		if err != nil {
+			return err
+		}
+		if !result.Changed() {
+			cmd.Printf("%s is up to date (version %d)\n", path, result.To)
+			continue
+		}
+		migrated++
+		cmd.Printf("%s: version %d -> %d\n", path, result.From, result.To)
+		for _, step := range result.Applied {
+			cmd.Printf("  - %s\n", step)
+		}
+		cmd.Print(result.Diff)
+		if result.Backup != "" {
+			cmd.Printf("Backed up original to %s\n", result.Backup)
+		}
+	}
+	if opts.dryRun && migrated > 0 {
+		cmd.Println("Dry run: no files were changed")
+	}
+	return nil
+}
+
+func configMigrateHelp() string {
+	return strings.Join([]string{
+		"Upgrade config files written for an older schema version.",
+		"",
+		"Without paths, the system, global and local config files for the current",
+		"directory that set a version are checked. Files without a version, theme",
+		"files and included files are left alone; pass their paths to migrate them.",
+		"Each upgraded file is backed up next to the original as",
+		"<file>.v<version>.bak and a diff of the changes is shown.",
+		"",
+		"Examples:",
+		"  go-cli-template config migrate --dry-run",
+		"  go-cli-template config migrate ~/.config/go-cli-template/config.toml",
+	}, "\n")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/go-cli-template/internal/config"
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/ui"
+)
+
+// configReloadedMsg carries the result of reloading config after a watched
+// file changed.
+type configReloadedMsg struct {
+	cfg      domain.Config
+	warnings []string
+	sources  []string
+	err      error
+}
+
+// reloadConfig re-runs Load in the background and reports the result.
+func reloadConfig(manager *config.ManagerImpl) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := manager.Load()
+		return configReloadedMsg{
+			cfg:      cfg,
+			warnings: manager.Warnings(),
+			sources:  manager.Sources(),
+			err:      err,
+		}
+	}
+}
+
+// applyConfigReload swaps in the reloaded theme and delegate. Load errors
+// keep the previous settings and are reported as an error notification.
+func (m directoryListModel) applyConfigReload(msg configReloadedMsg) (directoryListModel, tea.Cmd) {
+	// Pick up newly included files, keeping the stamps taken before the
+	// load so writes made while it ran still trigger another reload
+	m.watcher = m.watcher.WithPaths(msg.sources)
+
+	if msg.err != nil {
+		return m, m.toasts.Push(ui.StatusError, "Config error: %v (keeping previous settings)", msg.err)
+	}
+
+	m.editor = newEditor(msg.cfg)
+	theme := ui.ThemeFromConfig(msg.cfg)
+	m.theme = theme
+	m.toasts.SetTheme(theme)
+	m.delegateOpts.Spacing = msg.cfg.ListSpacing
+	m.list.SetDelegate(ui.NewListDelegate(theme, m.delegateOpts))
+	ui.ApplyListStyles(&m.list, theme)
+
+	listKeys := m.baseListKeys
+	keys, keyWarnings := ui.NewKeyMap(msg.cfg.Keymap, msg.cfg.Keys, &listKeys)
+	m.list.KeyMap = listKeys
+	// Let the list re-enable bindings for its current filter state and pages
+	m.list.SetFilteringEnabled(m.list.FilteringEnabled())
+	m.keys = keys
+	msg.warnings = append(msg.warnings, keyWarnings...)
+
+	if len(msg.warnings) > 0 {
+		return m, m.toasts.Push(ui.StatusWarning, "Config reloaded with warnings:\n%s", strings.Join(msg.warnings, "\n"))
+	}
+	return m, m.toasts.Push(ui.StatusSuccess, "Config reloaded")
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/testutil"
+	"github.com/go-cli-template/internal/utils"
+)
+
+func TestConfigInitHonorsConfigFlag(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "init", "--config", path)
+	if err != nil {
+		t.Fatalf("config init: %v\n%s", err, out)
+	}
+	if !pathExists(path) {
+		t.Fatalf("expected config at %s", path)
+	}
+	if pathExists(utils.ConfigPathGlobal()) {
+		t.Error("expected global config to be left untouched")
+	}
+}
+
+func TestConfigInitListsEveryKeyAction(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+
+	if out, err := testutil.RunCLI(t, newRootCmd(), "config", "init", "--config", path); err != nil {
+		t.Fatalf("config init: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range domain.KeyActions {
+		if !strings.Contains(string(data), " "+action+",") && !strings.Contains(string(data), " "+action+".") {
+			t.Errorf("expected key action %q in template:\n%s", action, data)
+		}
+	}
+}
+
+func TestConfigInitLocalAndGlobalAreExclusive(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+
+	if _, err := testutil.RunCLI(t, newRootCmd(), "config", "init", "--local", "--global"); err == nil {
+		t.Fatal("expected error when combining --local and --global")
+	}
+}
+
+func TestConfigListHonorsConfigFlag(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+	if err := os.WriteFile(path, []byte("editor = \"helix\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "list", "--config", path)
+	if err != nil {
+		t.Fatalf("config list: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "helix") {
+		t.Errorf("expected output to use --config file, got:\n%s", out)
+	}
+}
+
+func TestConfigConvertToYAML(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "config.toml")
+	if err := os.WriteFile(path, []byte("editor = \"helix\"\n\n[profiles.dark]\nmuted = \"01\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "convert", "--to", "yaml", path)
+	if err != nil {
+		t.Fatalf("config convert: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "config.yaml"))
+	if err != nil {
+		t.Fatalf("read converted config: %v", err)
+	}
+	if !strings.Contains(string(data), "editor: helix") || !strings.Contains(string(data), "dark:") {
+		t.Errorf("unexpected converted config:\n%s", data)
+	}
+	if pathExists(path) || !pathExists(path+".bak") {
+		t.Error("expected original config to be moved to .bak")
+	}
+}
+
+func TestConfigConvertJSONToTOML(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "config.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "editor": "helix"}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(path+".bak", []byte("older backup\n"), 0o644); err != nil {
+		t.Fatalf("write backup: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "convert", "--to", "toml", path)
+	if err != nil {
+		t.Fatalf("config convert: %v\n%s", err, out)
+	}
+	target := filepath.Join(root, "config.toml")
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("read converted config: %v", err)
+	}
+	if !strings.Contains(string(data), "version = 1\n") {
+		t.Errorf("expected version to stay an integer, got:\n%s", data)
+	}
+	if !pathExists(path + "-1.bak") {
+		t.Errorf("expected original config to be moved to a new backup, got:\n%s", out)
+	}
+	if backup, _ := os.ReadFile(path + ".bak"); string(backup) != "older backup\n" {
+		t.Errorf("existing backup was overwritten with:\n%s", backup)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "--config", target, "config", "list")
+	if err != nil {
+		t.Fatalf("config list: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "helix") {
+		t.Errorf("expected converted config to load, got:\n%s", out)
+	}
+}
+
+func TestConfigMigrateDryRun(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+	if err := os.WriteFile(path, []byte("editor = \"helix\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "migrate", "--dry-run", path)
+	if err != nil {
+		t.Fatalf("config migrate: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "+version = 1") || !strings.Contains(out, "Dry run") {
+		t.Errorf("expected diff in dry run output, got:\n%s", out)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "version") {
+		t.Error("expected dry run to leave the file unchanged")
+	}
+}
+
+func TestConfigMigrateSkipsThemesIncludesAndUnversionedFiles(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	globalPath := utils.ConfigPathGlobal()
+	configDir := filepath.Dir(globalPath)
+	themePath := filepath.Join(configDir, "themes", "mine.toml")
+	if err := os.MkdirAll(filepath.Dir(themePath), 0o755); err != nil {
+		t.Fatalf("mkdir themes dir: %v", err)
+	}
+	theme := "scheme = \"mine\"\nbase00 = \"#000000\"\nbase02 = \"#222222\"\nbase03 = \"#333333\"\nbase05 = \"#555555\"\nbase08 = \"#880000\"\nbase0A = \"#aa0000\"\nbase0B = \"#bb0000\"\nbase0C = \"#cc0000\"\nbase0D = \"#dd0000\"\nbase0E = \"#ee0000\"\n"
+	files := map[string]string{
+		globalPath:                             "version = 0\ntheme = \"mine\"\ninclude = [\"extra.toml\"]\n",
+		themePath:                              theme,
+		filepath.Join(configDir, "extra.toml"): "editor = \"helix\"\n",
+		utils.ConfigPathLocal(root):            "muted = \"01\"\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "migrate")
+	if err != nil {
+		t.Fatalf("config migrate: %v\n%s", err, out)
+	}
+	if data, _ := os.ReadFile(globalPath); !strings.Contains(string(data), "version = 1") {
+		t.Errorf("expected global config to be migrated, got:\n%s", data)
+	}
+	for path, content := range files {
+		if path == globalPath {
+			continue
+		}
+		if data, _ := os.ReadFile(path); string(data) != content {
+			t.Errorf("expected %s to be untouched, got:\n%s", path, data)
+		}
+		if pathExists(path + ".v0.bak") {
+			t.Errorf("expected no backup of %s", path)
+		}
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "config", "list")
+	if err != nil {
+		t.Fatalf("config list: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "#bb0000") || strings.Contains(out, "palette value") {
+		t.Errorf("expected theme colors after migrate, got:\n%s", out)
+	}
+}
+
+func TestConfigDiff(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	globalPath := utils.ConfigPathGlobal()
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	if err := os.WriteFile(globalPath, []byte("version = 1\neditor = \"hélix\"\n"), 0o644); err != nil {
+		t.Fatalf("write global config: %v", err)
+	}
+	localPath := utils.ConfigPathLocal(root)
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		t.Fatalf("mkdir local dir: %v", err)
+	}
+	if err := os.WriteFile(localPath, []byte("version = 1\neditor = \"vim\"\nmuted = \"01\"\n"), 0o644); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "config", "diff", "--format", "json", "--changed")
+	if err != nil {
+		t.Fatalf("config diff: %v\n%s", err, out)
+	}
+	var diffs []map[string]any
+	if err := json.Unmarshal([]byte(out), &diffs); err != nil {
+		t.Fatalf("parse json output: %v\n%s", err, out)
+	}
+	if len(diffs) != 2 {
+		t.Fatalf("expected editor and muted to differ, got %v", diffs)
+	}
+	editor := diffs[0]
+	if editor["key"] != "editor" || editor["default"] != "nvim" || editor["global"] != "hélix" || editor["local"] != "vim" || editor["effective"] != "vim" {
+		t.Errorf("unexpected editor diff: %v", editor)
+	}
+	if muted := diffs[1]; muted["key"] != "muted" || muted["global"] != nil {
+		t.Errorf("unexpected muted diff: %v", muted)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "config", "diff")
+	if err != nil {
+		t.Fatalf("config diff: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "EFFECTIVE") || !strings.Contains(out, `"hélix"`) {
+		t.Errorf("expected table output, got:\n%s", out)
+	}
+	// Columns line up by display width, not bytes
+	var header, editorRow string
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "KEY"):
+			header = line
+		case strings.HasPrefix(line, "editor "):
+			editorRow = line
+		}
+	}
+	headerWidth := lipgloss.Width(header[:strings.Index(header, "EFFECTIVE")])
+	rowWidth := lipgloss.Width(editorRow[:strings.LastIndex(editorRow, `"vim"`)])
+	if headerWidth != rowWidth {
+		t.Errorf("expected effective column at %d, got %d:\n%s", headerWidth, rowWidth, out)
+	}
+}
+
+func TestThemeListAndPreview(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+	themePath := filepath.Join(filepath.Dir(utils.ConfigPathGlobal()), "themes", "mine.toml")
+	if err := os.MkdirAll(filepath.Dir(themePath), 0o755); err != nil {
+		t.Fatalf("mkdir themes dir: %v", err)
+	}
+	if err := os.WriteFile(themePath, []byte("primary = \"#123456\"\n"), 0o644); err != nil {
+		t.Fatalf("write theme: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "theme", "list")
+	if err != nil {
+		t.Fatalf("theme list: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "mine") || !strings.Contains(out, "* default") {
+		t.Errorf("expected user theme and active default theme, got:\n%s", out)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "theme", "preview", "mine", "nord")
+	if err != nil {
+		t.Fatalf("theme preview: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "nord") || !strings.Contains(out, "README.md") {
+		t.Errorf("expected previews, got:\n%s", out)
+	}
+
+	if _, err := testutil.RunCLI(t, newRootCmd(), "theme", "preview", "missing"); err == nil {
+		t.Error("expected error for an unknown theme")
+	}
+}
+
+func TestConfigRevalidatesAfterEditing(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+	interactive := isInteractive
+	isInteractive = func() bool { return false }
+	t.Cleanup(func() { isInteractive = interactive })
+
+	t.Run("reports invalid values", func(t *testing.T) {
+		content := "version = 1\neditor = \"true\"\nlist_spacing = \"roomy\"\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		out, err := testutil.RunCLI(t, newRootCmd(), "config", "--config", path)
+		if err != nil {
+			t.Fatalf("config: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, "list_spacing") || !strings.Contains(out, "Opened config") {
+			t.Errorf("expected the warning and the file to be reported, got:\n%s", out)
+		}
+	})
+
+	t.Run("fails when the edit breaks the file", func(t *testing.T) {
+		content := "version = 1\neditor = \"sh -c 'echo \\\"[broken\\\" >> \\\"$0\\\"'\"\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		out, err := testutil.RunCLI(t, newRootCmd(), "config", "--config", path)
+		if err == nil {
+			t.Fatalf("expected the broken config to be reported, got:\n%s", out)
+		}
+		if strings.Contains(out, "Opened config") {
+			t.Errorf("expected no success message, got:\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/adapters/clipboard"
+	"github.com/go-cli-template/internal/adapters/editor"
+	"github.com/go-cli-template/internal/adapters/icon"
+	"github.com/go-cli-template/internal/adapters/shell"
+	"github.com/go-cli-template/internal/adapters/tty"
+	"github.com/go-cli-template/internal/config"
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/ui"
+	"github.com/go-cli-template/internal/utils"
+)
+
+type checkStatus string
+
+const (
+	checkPass checkStatus = "pass"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+)
+
+// doctorCheck is a single line of the doctor checklist.
+type doctorCheck struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+	Details []string    `json:"details,omitempty"`
+}
+
+type doctorOptions struct {
+	json bool
+}
+
+func newDoctorCmd() *cobra.Command {
+	opts := &doctorOptions{}
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose configuration and environment problems",
+		Long:  doctorHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.json, "json", false, "print results as JSON")
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, opts *doctorOptions) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	manager := newConfigManager(cmd, cwd)
+	configCheck, cfg := checkConfig(manager)
+	checks := []doctorCheck{
+		configCheck,
+		checkEditor(cfg),
+		checkShell(),
+		checkClipboard(),
+		checkTTY(),
+		checkColorProfile(colorFlag(cmd)),
+		checkNerdFont(),
+		checkXDGDirs(),
+	}
+
+	if opts.json {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(data))
+	} else {
+		cmd.Print(renderDoctorChecks(checks, ui.ThemeFromConfig(cfg)))
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("doctor found %d failing check(s)", failed)
+	}
+	return nil
+}
+
+// checkConfig loads config and reports the files that were read. The
+// loaded config is returned so later checks use the user's settings.
+func checkConfig(manager *config.ManagerImpl) (doctorCheck, domain.Config) {
+	check := doctorCheck{Name: "config"}
+	cfg, err := manager.Load()
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		return check, domain.DefaultConfig()
+	}
+	for _, path := range manager.Sources() {
+		if pathExists(path) {
+			check.Details = append(check.Details, path)
+		}
+	}
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("%d file(s) loaded", len(check.Details))
+	if len(check.Details) == 0 {
+		check.Message = "no config files found; using defaults"
+	}
+	if warnings := manager.Warnings(); len(warnings) > 0 {
+		check.Status = checkWarn
+		check.Message += fmt.Sprintf(" with %d warning(s)", len(warnings))
+		check.Details = append(check.Details, warnings...)
+	}
+	return check, cfg
+}
+
+func checkEditor(cfg domain.Config) doctorCheck {
+	check := doctorCheck{Name: "editor"}
+	command := editor.ResolveCommand(cfg.Editor)
+	if strings.TrimSpace(command) == "" {
+		check.Status = checkFail
+		check.Message = "no editor configured; set editor in config or $VISUAL/$EDITOR"
+		return check
+	}
+	fields, err := editor.SplitCommand(command)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		return check
+	}
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("%q not found on PATH", fields[0])
+		return check
+	}
+	check.Status = checkPass
+	check.Message = command
+	check.Details = []string{path}
+	return check
+}
+
+func checkShell() doctorCheck {
+	detected := shell.DetectShell()
+	if parent := shell.ParentShell(); parent != "" {
+		check := doctorCheck{Name: "shell", Status: checkPass, Message: detected}
+		if login := os.Getenv("SHELL"); login != "" && shell.New(filepath.Base(login)).Shell() != parent {
+			check.Details = []string{fmt.Sprintf("started from %s; $SHELL is %s", parent, login)}
+		}
+		return check
+	}
+	if os.Getenv("SHELL") == "" {
+		return doctorCheck{
+			Name:    "shell",
+			Status:  checkWarn,
+			Message: fmt.Sprintf("%s (guessed; $SHELL is not set)", detected),
+		}
+	}
+	return doctorCheck{Name: "shell", Status: checkPass, Message: detected}
+}
+
+func checkClipboard() doctorCheck {
+	if !(clipboard.Adapter{}).Available() {
+		return doctorCheck{
+			Name:    "clipboard",
+			Status:  checkWarn,
+			Message: "no clipboard tool found; install xclip, xsel or wl-clipboard",
+		}
+	}
+	return doctorCheck{Name: "clipboard", Status: checkPass, Message: "available"}
+}
+
+func checkTTY() doctorCheck {
+	var missing []string
+	if !tty.IsTerminal(os.Stdin.Fd()) {
+		missing = append(missing, "stdin")
+	}
+	if !tty.IsTerminal(os.Stdout.Fd()) {
+		missing = append(missing, "stdout")
+	}
+	if len(missing) > 0 {
+		return doctorCheck{
+			Name:    "tty",
+			Status:  checkWarn,
+			Message: strings.Join(missing, " and ") + " not a terminal; the browser falls back to /dev/tty",
+		}
+	}
+	return doctorCheck{Name: "tty", Status: checkPass, Message: "stdin and stdout are terminals"}
+}
+
+func checkColorProfile(mode string) doctorCheck {
+	check := doctorCheck{Name: "colors", Status: checkPass}
+	switch lipgloss.ColorProfile() {
+	case termenv.TrueColor:
+		check.Message = "true color"
+	case termenv.ANSI256:
+		check.Message = "256 colors; hex colors are approximated"
+	case termenv.ANSI:
+		check.Status = checkWarn
+		check.Message = "16 colors; hex and numeric colors above 15 are approximated"
+	default:
+		switch {
+		case mode == ui.ColorNever:
+			check.Message = "disabled by --color=never"
+		case mode != ui.ColorAlways && os.Getenv("NO_COLOR") != "":
+			check.Message = "disabled by NO_COLOR"
+		default:
+			check.Status = checkWarn
+			check.Message = "no color support detected; output is uncolored"
+		}
+	}
+	if lipgloss.ColorProfile() != termenv.Ascii {
+		background := "dark"
+		if !lipgloss.HasDarkBackground() {
+			background = "light"
+		}
+		check.Details = append(check.Details, background+" background")
+	}
+	for _, name := range []string{"TERM", "NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE"} {
+		if value := os.Getenv(name); value != "" {
+			check.Details = append(check.Details, name+"="+value)
+		}
+	}
+	return check
+}
+
+// checkNerdFont cannot inspect the terminal font, so it shows a glyph for
+// the user to verify and only warns on terminals known to lack the glyphs.
+func checkNerdFont() doctorCheck {
+	switch os.Getenv("TERM") {
+	case "linux", "dumb":
+		return doctorCheck{
+			Name:    "nerd font",
+			Status:  checkWarn,
+			Message: "this terminal cannot show Nerd Font icons",
+		}
+	}
+	return doctorCheck{
+		Name:    "nerd font",
+		Status:  checkPass,
+		Message: fmt.Sprintf("icons need a Nerd Font; %s should show a file icon", icon.File),
+	}
+}
+
+func checkXDGDirs() doctorCheck {
+	check := doctorCheck{Name: "xdg dirs", Status: checkPass}
+	dirs := []struct{ name, path string }{
+		{"config", utils.XDGConfigHome()},
+		{"data", utils.XDGDataHome()},
+		{"cache", utils.XDGCacheHome()},
+	}
+	var failed []string
+	for _, dir := range dirs {
+		if err := checkWritable(dir.path); err != nil {
+			failed = append(failed, dir.name)
+			check.Details = append(check.Details, fmt.Sprintf("%s: %s: %v", dir.name, dir.path, err))
+			continue
+		}
+		check.Details = append(check.Details, fmt.Sprintf("%s: %s", dir.name, dir.path))
+	}
+	check.Message = "config, data and cache directories are writable"
+	if len(failed) > 0 {
+		check.Status = checkFail
+		check.Message = strings.Join(failed, ", ") + " not writable"
+	}
+	return check
+}
+
+// checkWritable creates and removes a temporary file in dir, or in its
+// nearest existing parent when dir has not been created yet.
+func checkWritable(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("could not determine directory")
+	}
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+	file, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return err
+	}
+	_ = file.Close()
+	return os.Remove(file.Name())
+}
+
+// renderDoctorChecks renders checks as a checklist with details indented
+// under each item.
+func renderDoctorChecks(checks []doctorCheck, theme ui.Theme) string {
+	symbols := map[checkStatus]string{
+		checkPass: lipgloss.NewStyle().Foreground(theme.Success).Render("✓"),
+		checkWarn: lipgloss.NewStyle().Foreground(theme.Warning).Render("!"),
+		checkFail: lipgloss.NewStyle().Foreground(theme.Error).Render("✗"),
+	}
+	name := lipgloss.NewStyle().Foreground(theme.Headings).Bold(true)
+	detail := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	width := 0
+	for _, check := range checks {
+		width = max(width, len(check.Name))
+	}
+	var builder strings.Builder
+	for _, check := range checks {
+		padding := strings.Repeat(" ", width-len(check.Name))
+		fmt.Fprintf(&builder, "%s %s%s  %s\n", symbols[check.Status], name.Render(check.Name), padding, check.Message)
+		for _, line := range check.Details {
+			fmt.Fprintf(&builder, "%s%s\n", strings.Repeat(" ", width+4), detail.Render(line))
+		}
+	}
+	return builder.String()
+}
+
+func doctorHelp() string {
+	return strings.Join([]string{
+		"Check the config, editor, shell, clipboard and terminal setup.",
+		"",
+		"Each item is reported as pass, warn or fail. The command exits with an",
+		"error when any check fails, so it can be used in scripts. Attach the",
+		"output (or --json) when reporting a bug.",
+		"",
+		"Examples:",
+		"  go-cli-template doctor",
+		"  go-cli-template doctor --json",
+	}, "\n")
+}
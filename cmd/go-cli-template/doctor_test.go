@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/testutil"
+)
+
+func TestDoctor(t *testing.T) {
+	testutil.WithTempXDG(t)
+	root := testutil.WithTempWorkspace(t)
+	path := filepath.Join(root, "custom.toml")
+	if err := os.WriteFile(path, []byte("version = 1\neditor = \"definitely-not-an-editor\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "doctor", "--config", path)
+	if err == nil {
+		t.Fatal("expected doctor to fail when the editor is missing")
+	}
+
+	names := []string{"config", "editor", "shell", "clipboard", "tty", "colors", "nerd font", "xdg dirs"}
+	// Check lines start with a status symbol followed by the check name
+	symbols := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		symbol, rest, ok := strings.Cut(line, " ")
+		if !ok || (symbol != "✓" && symbol != "!" && symbol != "✗") {
+			continue
+		}
+		for _, name := range names {
+			if strings.HasPrefix(rest, name+"  ") {
+				symbols[name] = symbol
+			}
+		}
+	}
+	for _, name := range names {
+		if _, ok := symbols[name]; !ok {
+			t.Errorf("expected %q check in output:\n%s", name, out)
+		}
+	}
+	if symbols["config"] != "✓" {
+		t.Errorf("expected config check to pass, got %q", symbols["config"])
+	}
+	if symbols["editor"] != "✗" {
+		t.Errorf("expected editor check to fail, got %q", symbols["editor"])
+	}
+	if symbols["xdg dirs"] != "✓" {
+		t.Errorf("expected temp XDG dirs to be writable, got %q", symbols["xdg dirs"])
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkWritable(filepath.Join(dir, "missing", "nested")); err != nil {
+		t.Errorf("expected missing directory under a writable parent to pass, got %v", err)
+	}
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := checkWritable(file); err == nil {
+		t.Error("expected error when the path is a file")
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
 	"time"
@@ -10,8 +13,11 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/go-cli-template/internal/adapters/editor"
+	"github.com/go-cli-template/internal/adapters/tty"
 	"github.com/go-cli-template/internal/config"
 	"github.com/go-cli-template/internal/domain"
 	pkg "github.com/go-cli-template/internal/package"
@@ -28,6 +34,8 @@ var (
 
 type rootOptions struct {
 	configPath  string
+	profile     string
+	color       string
 	showVersion bool
 }
 
@@ -44,6 +52,9 @@ func newRootCmd() *cobra.Command {
 		Use:   name,
 		Short: short,
 		Args:  cobra.ArbitraryArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return ui.SetupColor(opts.color)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.showVersion {
 				ver := resolvedVersion()
@@ -62,7 +73,28 @@ func newRootCmd() *cobra.Command {
 	//
 	// 		Define a config.toml file to use instead of the global one.
 	//	flags: config
-	cmd.Flags().StringVarP(&opts.configPath, "config", "c", "", "config file path")
+	cmd.PersistentFlags().StringVarP(&opts.configPath, "config", "c", "", "config file path")
+
+	// @docs-flag-group
+	//
+	// 	name: Profile
+	// 	description:
+	//
+	// 		Overlay a named [profiles.<name>] table from config.toml.
+	//	flags: profile
+	cmd.PersistentFlags().StringVarP(&opts.profile, "profile", "p", "", "config profile to apply")
+
+	// @docs-flag-group
+	//
+	// 	name: Color
+	// 	description:
+	//
+	// 		Choose when output is colored. auto honors NO_COLOR and CLICOLOR_FORCE.
+	//	flags: color
+	cmd.PersistentFlags().StringVar(&opts.color, "color", ui.ColorAuto, "colorize output: auto, always or never")
+	_ = cmd.RegisterFlagCompletionFunc("color", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return ui.ColorModes, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// @docs-flag-group
 	//
@@ -72,6 +104,10 @@ func newRootCmd() *cobra.Command {
 
 	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newCompletionCmd())
+	cmd.AddCommand(newDoctorCmd())
+	cmd.AddCommand(newSearchCmd())
+	cmd.AddCommand(newShellCmd())
+	cmd.AddCommand(newThemeCmd())
 
 	return cmd
 }
@@ -88,27 +124,86 @@ func resolvedVersion() string {
 	return ver
 }
 
-func runInteractive(cmd *cobra.Command, opts *rootOptions, args []string) error {
-	cwd, err := os.Getwd()
+// profileFlag returns the persistent --profile value for any subcommand.
+func profileFlag(cmd *cobra.Command) string {
+	profile, err := cmd.Flags().GetString("profile")
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return ""
+	}
+	return profile
+}
+
+// configFlag returns the persistent --config value for any subcommand.
+func configFlag(cmd *cobra.Command) string {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// colorFlag returns the persistent --color value for any subcommand.
+func colorFlag(cmd *cobra.Command) string {
+	mode, err := cmd.Flags().GetString("color")
+	if err != nil {
+		return ui.ColorAuto
+	}
+	return mode
+}
+
+// newConfigManager builds a config manager honoring --config and --profile.
+func newConfigManager(cmd *cobra.Command, cwd string) *config.ManagerImpl {
+	return config.NewManager(cwd).
+		WithProfile(profileFlag(cmd)).
+		WithConfigPath(configFlag(cmd))
+}
+
+// loadConfigOrDefault loads config, reporting failures as a warning and
+// falling back to defaults so a broken config never blocks the command.
+func loadConfigOrDefault(cmd *cobra.Command, manager *config.ManagerImpl) domain.Config {
+	cfg, err := manager.Load()
+	if err != nil {
+		printWarning(cmd, "failed to load config: %v (using defaults)", err)
+		return domain.DefaultConfig()
 	}
+	printConfigWarnings(cmd, manager)
+	return cfg
+}
+
+// newEditor returns the editor adapter configured by cfg.
+func newEditor(cfg domain.Config) *editor.Adapter {
+	return editor.New(cfg.Editor).
+		WithLineTemplate(cfg.EditorLineTemplate).
+		WithLayout(cfg.EditorLayout).
+		WithWait(cfg.EditorWait).
+		WithDiffTool(cfg.DiffTool)
+}
 
-	manager := config.NewManager(cwd)
-	var cfg domain.Config
-	if opts.configPath != "" {
-		cfg, err = manager.LoadWithOverride(opts.configPath)
-	} else {
-		cfg, err = manager.Load()
+// printConfigWarnings reports non-fatal problems found by the last Load.
+func printConfigWarnings(cmd *cobra.Command, manager *config.ManagerImpl) {
+	for _, warning := range manager.Warnings() {
+		printWarning(cmd, "%s", warning)
 	}
+}
+
+// printWarning writes a non-fatal warning to stderr.
+func printWarning(cmd *cobra.Command, format string, args ...any) {
+	cmd.PrintErrf("Warning: "+format+"\n", args...)
+}
+
+func runInteractive(cmd *cobra.Command, opts *rootOptions, args []string) error {
+	cwd, err := os.Getwd()
 	if err != nil {
-		cfg = domain.DefaultConfig()
+		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	return runDirectoryListing(cwd, cfg)
+	manager := newConfigManager(cmd, cwd)
+	cfg := loadConfigOrDefault(cmd, manager)
+
+	return runDirectoryListing(cwd, cfg, manager)
 }
 
-func runDirectoryListing(cwd string, cfg domain.Config) error {
+func runDirectoryListing(cwd string, cfg domain.Config, manager *config.ManagerImpl) error {
 	entries, err := os.ReadDir(cwd)
 	if err != nil {
 		return fmt.Errorf("failed to read directory: %w", err)
@@ -133,27 +228,37 @@ func runDirectoryListing(cwd string, cfg domain.Config) error {
 	}
 
 	theme := ui.ThemeFromConfig(cfg)
-	delegate := ui.NewListDelegate(theme, ui.ListDelegateOptions{
-		Spacing: cfg.ListSpacing,
-	})
-
-	listModel := ui.NewListModel(items, delegate, 80, 20, theme)
+	delegateOpts := ui.ListDelegateOptions{Spacing: cfg.ListSpacing}
+	listModel := ui.NewListModel(items, ui.NewListDelegate(theme, delegateOpts), 80, 20, theme)
 	listModel.Title = fmt.Sprintf("Directory: %s", cwd)
 	listModel.SetShowStatusBar(true)
 	listModel.SetFilteringEnabled(true)
+	baseListKeys := listModel.KeyMap
+	keys, keyWarnings := ui.NewKeyMap(cfg.Keymap, cfg.Keys, &listModel.KeyMap)
 
 	model := directoryListModel{
-		list:       listModel,
-		theme:      theme,
-		responsive: ui.NewResponsiveManager(80),
-		cwd:        cwd,
+		list:         listModel,
+		delegateOpts: delegateOpts,
+		baseListKeys: baseListKeys,
+		theme:        theme,
+		keys:         keys,
+		responsive:   ui.NewResponsiveManager(80),
+		cwd:          cwd,
+		editor:       newEditor(cfg),
+		manager:      manager,
+		watcher:      ui.NewFileWatcher(manager.Sources(), ui.DefaultWatchInterval),
+		toasts:       ui.NewToasts(theme),
+	}
+	if len(keyWarnings) > 0 {
+		// Started by Init once the program runs
+		_ = model.toasts.Push(ui.StatusWarning, "Key binding conflicts:\n%s", strings.Join(keyWarnings, "\n"))
 	}
 
 	// Set initial keybindings based on initial screen size
 	model.list.AdditionalShortHelpKeys = model.getShortHelpKeys
 	model.list.AdditionalFullHelpKeys = model.allHelpKeys
 
-	p := tea.NewProgram(model, tea.WithoutSignalHandler())
+	p := tea.NewProgram(model, tty.GetProgramOptions(tea.WithoutSignalHandler())...)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run interactive list: %w", err)
 	}
@@ -165,13 +270,19 @@ type fileItem struct {
 	name    string
 	isDir   bool
 	modTime time.Time
+	marked  bool
 }
 
 func (f fileItem) Title() string {
+	title := f.name
 	if f.isDir {
-		return f.name + "/"
+		title += "/"
 	}
-	return f.name
+	// Marks go after the name so filter highlights stay aligned
+	if f.marked {
+		title += " ✓"
+	}
+	return title
 }
 
 func (f fileItem) Description() string {
@@ -184,11 +295,21 @@ func (f fileItem) FilterValue() string {
 
 type directoryListModel struct {
 	list          list.Model
+	delegateOpts  ui.ListDelegateOptions
+	baseListKeys  list.KeyMap
 	theme         ui.Theme
+	keys          ui.KeyMap
 	responsive    *ui.ResponsiveManager
 	cwd           string
+	editor        *editor.Adapter
+	manager       *config.ManagerImpl
+	watcher       ui.FileWatcher
 	selected      string
-	message       string
+	toasts        ui.Toasts
+	showHistory   bool
+	diffView      *ui.DiffView
+	width         int
+	height        int
 	confirmMode   bool
 	confirmModel  *ui.ConfirmationModel
 	pendingAction string
@@ -197,28 +318,7 @@ type directoryListModel struct {
 
 // allHelpKeys returns the complete list of keybindings in priority order
 func (m directoryListModel) allHelpKeys() []key.Binding {
-	return []key.Binding{
-		key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "view file/directory"),
-		),
-		key.NewBinding(
-			key.WithKeys("a"),
-			key.WithHelp("a", "add new file"),
-		),
-		key.NewBinding(
-			key.WithKeys("d"),
-			key.WithHelp("d", "delete file"),
-		),
-		key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "rename file"),
-		),
-		key.NewBinding(
-			key.WithKeys("o"),
-			key.WithHelp("o", "open in editor"),
-		),
-	}
+	return m.keys.HelpKeys()
 }
 
 // getShortHelpKeys returns keybindings for short help based on screen size
@@ -235,7 +335,7 @@ func (m directoryListModel) getShortHelpKeys() []key.Binding {
 		splitAt = 1
 	}
 
-	return allKeys[:splitAt]
+	return allKeys[:min(splitAt, len(allKeys))]
 }
 
 // getFullHelpKeys returns keybindings for full help
@@ -256,14 +356,62 @@ func (m directoryListModel) getFullHelpKeys() []key.Binding {
 		return []key.Binding{}
 	}
 
-	return allKeys[splitAt:]
+	return allKeys[min(splitAt, len(allKeys)):]
 }
 
 func (m directoryListModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.watcher.Watch(), m.toasts.Init())
 }
 
 func (m directoryListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Config hot-reload runs regardless of the active view
+	switch msg := msg.(type) {
+	case ui.FilesChangedMsg:
+		m.watcher = msg.Watcher
+		return m, reloadConfig(m.manager)
+	case configReloadedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.applyConfigReload(msg)
+		return m, tea.Batch(cmd, m.watcher.Watch())
+	}
+	if cmd, ok := m.watcher.Continue(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.toasts.Update(msg); ok {
+		return m, cmd
+	}
+	if msg, ok := msg.(editorFinishedMsg); ok {
+		if msg.err != nil {
+			return m, m.toasts.Push(ui.StatusError, "Editor failed: %v", msg.err)
+		}
+		return m, nil
+	}
+
+	// The message history covers the list until it is closed
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.showHistory {
+		switch {
+		case key.Matches(keyMsg, m.list.KeyMap.ForceQuit):
+			return m, tea.Quit
+		case m.keys.Matches(keyMsg, ui.KeyHistory), m.keys.Matches(keyMsg, ui.KeyQuit):
+			m.showHistory = false
+		}
+		return m, nil
+	}
+
+	// The diff viewer covers the list until it is closed
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.diffView != nil {
+		switch {
+		case key.Matches(keyMsg, m.list.KeyMap.ForceQuit):
+			return m, tea.Quit
+		case m.keys.Matches(keyMsg, ui.KeyDiff), m.keys.Matches(keyMsg, ui.KeyQuit):
+			m.diffView = nil
+			return m, nil
+		}
+		view, cmd := m.diffView.Update(keyMsg)
+		m.diffView = &view
+		return m, cmd
+	}
+
 	// Handle confirmation dialog if active
 	if m.confirmMode && m.confirmModel != nil {
 		switch msg := msg.(type) {
@@ -278,9 +426,9 @@ func (m directoryListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if confirmed {
 							return m.executeAction()
 						} else {
-							m.message = fmt.Sprintf("%s cancelled", m.pendingAction)
+							cmd := m.toasts.Push(ui.StatusInfo, "%s cancelled", m.pendingAction)
 							m.pendingAction = ""
-							return m, nil
+							return m, cmd
 						}
 					}
 				}
@@ -295,30 +443,39 @@ func (m directoryListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update responsive manager with new width
 		m.responsive.SetWidth(msg.Width)
 
+		m.width = msg.Width
+		m.height = msg.Height
+
 		// Get responsive list dimensions
 		width, height := m.responsive.GetListDimensions(msg.Width, msg.Height)
 		m.list.SetSize(width, height)
+		if m.diffView != nil {
+			m.diffView.SetSize(width, height)
+		}
 
 		// Update keybindings based on new screen size
 		m.list.AdditionalShortHelpKeys = m.getShortHelpKeys
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "ctrl+c":
+		// Let typed characters reach the filter input
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case m.keys.Matches(msg, ui.KeyQuit):
 			return m, tea.Quit
-		case "enter":
+		case m.keys.Matches(msg, ui.KeyView):
 			// View file
 			if item, ok := m.list.SelectedItem().(fileItem); ok {
 				m.selected = item.name
 				if !item.isDir {
-					m.message = fmt.Sprintf("Viewing: %s", item.name)
 					// TODO: Implement file viewing
-				} else {
-					m.message = fmt.Sprintf("Directory: %s", item.name)
+					return m, m.toasts.Push(ui.StatusInfo, "Viewing: %s", item.name)
 				}
+				return m, m.toasts.Push(ui.StatusInfo, "Directory: %s", item.name)
 			}
-		case "d":
+		case m.keys.Matches(msg, ui.KeyDelete):
 			// Delete file - show confirmation
 			if item, ok := m.list.SelectedItem().(fileItem); ok {
 				m.pendingAction = "Delete"
@@ -332,7 +489,7 @@ func (m directoryListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmMode = true
 				return m, confirmModel.Init()
 			}
-		case "r":
+		case m.keys.Matches(msg, ui.KeyRename):
 			// Rename file - show confirmation
 			if item, ok := m.list.SelectedItem().(fileItem); ok {
 				m.pendingAction = "Rename"
@@ -346,21 +503,47 @@ func (m directoryListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmMode = true
 				return m, confirmModel.Init()
 			}
-		case "o":
-			// Open file in editor
+		case m.keys.Matches(msg, ui.KeyOpen):
+			// Open the marked files, or the selected one, in the editor
+			if paths := m.markedFiles(); len(paths) > 0 {
+				return m, m.openFiles(paths)
+			}
 			if item, ok := m.list.SelectedItem().(fileItem); ok {
-				m.message = fmt.Sprintf("Open: %s (not implemented)", item.name)
-				// TODO: Implement file opening
+				if item.isDir {
+					return m, m.toasts.Push(ui.StatusWarning, "Cannot open a directory: %s", item.name)
+				}
+				return m, m.openFiles([]string{filepath.Join(m.cwd, item.name)})
 			}
-		case "a":
+		case m.keys.Matches(msg, ui.KeyMark):
+			if item, ok := m.list.SelectedItem().(fileItem); ok {
+				item.marked = !item.marked
+				cmd := m.list.SetItem(m.list.GlobalIndex(), item)
+				m.list.CursorDown()
+				m.updateTitle()
+				return m, cmd
+			}
+		case m.keys.Matches(msg, ui.KeyAdd):
 			// Add new file
-			m.message = "Add file (not implemented)"
 			// TODO: Implement file creation
+			return m, m.toasts.Push(ui.StatusWarning, "Add file (not implemented)")
+		case m.keys.Matches(msg, ui.KeyDiff):
+			paths := m.markedFiles()
+			if len(paths) != 2 {
+				return m, m.toasts.Push(ui.StatusWarning, "Mark two files to diff (%d marked)", len(paths))
+			}
+			return m, m.diffFiles(paths[0], paths[1])
+		case m.keys.Matches(msg, ui.KeyHistory):
+			m.showHistory = true
+			return m, nil
 		}
 	}
 
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
+	if filtered := m.list.FilterState() != list.Unfiltered; filtered != m.delegateOpts.Filtered {
+		m.delegateOpts.Filtered = filtered
+		m.list.SetDelegate(ui.NewListDelegate(m.theme, m.delegateOpts))
+	}
 	return m, cmd
 }
 
@@ -370,25 +553,143 @@ func (m directoryListModel) View() string {
 		return m.confirmModel.View()
 	}
 
+	if m.showHistory {
+		help := m.keys.Binding(ui.KeyHistory).Help().Key
+		if quit := m.keys.Binding(ui.KeyQuit).Help().Key; quit != "" {
+			help += "/" + quit
+		}
+		history := m.toasts.HistoryView(m.height-6) + "\n\n" +
+			lipgloss.NewStyle().Foreground(m.theme.Muted).Render(help+" close")
+		return m.responsive.AdaptiveFrameStyle(m.theme).Render(history)
+	}
+
+	if m.diffView != nil {
+		help := m.keys.Binding(ui.KeyDiff).Help().Key
+		if quit := m.keys.Binding(ui.KeyQuit).Help().Key; quit != "" {
+			help += "/" + quit
+		}
+		view := m.diffView.View() + "\n" +
+			lipgloss.NewStyle().Foreground(m.theme.Muted).Render(help+" close")
+		return m.responsive.AdaptiveFrameStyle(m.theme).Render(view)
+	}
+
 	listView := m.list.View()
 
-	// Add message if present
-	if m.message != "" {
-		listView = listView + "\n\n" + m.message
+	// Add the current notification if present
+	if toast := m.toasts.View(); toast != "" {
+		listView = listView + "\n\n" + toast
 	}
 
 	return m.responsive.AdaptiveFrameStyle(m.theme).Render(listView)
 }
 
+// editorFinishedMsg reports that an editor started from the browser exited.
+type editorFinishedMsg struct {
+	err error
+}
+
+// markedFiles returns the paths of the marked files in list order.
+// Marked directories are skipped.
+func (m directoryListModel) markedFiles() []string {
+	var paths []string
+	for _, listItem := range m.list.Items() {
+		if item, ok := listItem.(fileItem); ok && item.marked && !item.isDir {
+			paths = append(paths, filepath.Join(m.cwd, item.name))
+		}
+	}
+	return paths
+}
+
+// markedCount returns the number of marked entries, directories included.
+func (m directoryListModel) markedCount() int {
+	count := 0
+	for _, listItem := range m.list.Items() {
+		if item, ok := listItem.(fileItem); ok && item.marked {
+			count++
+		}
+	}
+	return count
+}
+
+// updateTitle shows the number of marked entries next to the directory.
+func (m *directoryListModel) updateTitle() {
+	m.list.Title = fmt.Sprintf("Directory: %s", m.cwd)
+	if count := m.markedCount(); count > 0 {
+		m.list.Title += fmt.Sprintf(" (%d marked)", count)
+	}
+}
+
+// openFiles suspends the browser and opens paths in one editor session.
+func (m *directoryListModel) openFiles(paths []string) tea.Cmd {
+	cmd, err := m.editor.OpenManyCmd(paths)
+	if err != nil {
+		return m.toasts.Push(ui.StatusError, "Cannot open editor: %v", err)
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// The built-in diff keeps a table of lines × lines, so larger files are
+// left to diff_tool.
+const (
+	maxBuiltinDiffSize  = 1 << 20
+	maxBuiltinDiffLines = 3000
+)
+
+// diffFiles compares two files with the diff tool or the editor's diff
+// mode, falling back to the built-in diff viewer when the editor has none.
+func (m *directoryListModel) diffFiles(left, right string) tea.Cmd {
+	cmd, err := m.editor.DiffCmd(left, right)
+	if err == nil {
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return editorFinishedMsg{err: err}
+		})
+	}
+	if !errors.Is(err, editor.ErrNoDiffMode) {
+		return m.toasts.Push(ui.StatusError, "Cannot open diff: %v", err)
+	}
+
+	before, err := os.ReadFile(left)
+	if err != nil {
+		return m.toasts.Push(ui.StatusError, "Cannot read %s: %v", filepath.Base(left), err)
+	}
+	after, err := os.ReadFile(right)
+	if err != nil {
+		return m.toasts.Push(ui.StatusError, "Cannot read %s: %v", filepath.Base(right), err)
+	}
+	leftName, rightName := filepath.Base(left), filepath.Base(right)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{{leftName, before}, {rightName, after}} {
+		if bytes.IndexByte(file.data, 0) >= 0 {
+			return m.toasts.Push(ui.StatusWarning, "%s is a binary file; set diff_tool to compare it", file.name)
+		}
+		if len(file.data) > maxBuiltinDiffSize || bytes.Count(file.data, []byte("\n")) > maxBuiltinDiffLines {
+			return m.toasts.Push(ui.StatusWarning, "%s is too large for the built-in diff; set diff_tool to compare it", file.name)
+		}
+	}
+	diff := utils.UnifiedDiff(leftName, rightName, string(before), string(after))
+	if diff == "" {
+		return m.toasts.Push(ui.StatusInfo, "%s and %s are identical", leftName, rightName)
+	}
+	width, height := m.responsive.GetListDimensions(m.width, m.height)
+	view := ui.NewDiffView(fmt.Sprintf("Diff: %s ↔ %s", leftName, rightName), diff, width, height, m.theme)
+	m.diffView = &view
+	return nil
+}
+
 func (m directoryListModel) executeAction() (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 	switch m.pendingAction {
 	case "Delete":
 		// TODO: Implement actual file deletion
-		m.message = fmt.Sprintf("✓ Deleted: %s (stub - not actually deleted)", m.pendingItem.name)
+		cmd = m.toasts.Push(ui.StatusSuccess, "Deleted: %s (stub - not actually deleted)", m.pendingItem.name)
 	case "Rename":
 		// TODO: Implement actual file renaming
-		m.message = fmt.Sprintf("✓ Renamed: %s (stub - not actually renamed)", m.pendingItem.name)
+		cmd = m.toasts.Push(ui.StatusSuccess, "Renamed: %s (stub - not actually renamed)", m.pendingItem.name)
 	}
 	m.pendingAction = ""
-	return m, nil
+	return m, cmd
 }
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/testutil"
+	"github.com/go-cli-template/internal/ui"
 )
 
 func TestRootCommandHasVersion(t *testing.T) {
@@ -17,9 +23,9 @@ func TestRootCommandHasVersion(t *testing.T) {
 
 func TestRootCommandHasConfig(t *testing.T) {
 	cmd := newRootCmd()
-	configFlag := cmd.Flags().Lookup("config")
+	configFlag := cmd.PersistentFlags().Lookup("config")
 	if configFlag == nil {
-		t.Fatal("expected --config flag to be registered")
+		t.Fatal("expected --config persistent flag to be registered")
 	}
 }
 
@@ -29,3 +35,50 @@ func TestResolvedVersion(t *testing.T) {
 		t.Fatal("expected version to be non-empty")
 	}
 }
+
+func TestRootCommandColorFlag(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+
+	if out, err := testutil.RunCLI(t, newRootCmd(), "--color", "never", "theme", "list"); err != nil {
+		t.Fatalf("--color never: %v\n%s", err, out)
+	}
+	out, err := testutil.RunCLI(t, newRootCmd(), "--color", "sometimes", "theme", "list")
+	if err == nil || !strings.Contains(err.Error(), "invalid color mode") {
+		t.Fatalf("expected invalid color mode error, got %v\n%s", err, out)
+	}
+}
+
+func TestRootCommandHasPersistentProfile(t *testing.T) {
+	cmd := newRootCmd()
+	profileFlag := cmd.PersistentFlags().Lookup("profile")
+	if profileFlag == nil {
+		t.Fatal("expected --profile persistent flag to be registered")
+	}
+}
+
+func TestConfigReloadKeepsListKeyState(t *testing.T) {
+	items := []list.Item{fileItem{name: "a.txt"}, fileItem{name: "b.txt"}}
+	theme := ui.ThemeFromConfig(domain.Config{})
+	listModel := ui.NewListModel(items, ui.NewListDelegate(theme, ui.ListDelegateOptions{}), 80, 20, theme)
+	listModel.SetFilteringEnabled(true)
+	listModel.KeyMap.Filter.SetKeys("ctrl+s")
+	m := directoryListModel{
+		list:         listModel,
+		baseListKeys: listModel.KeyMap,
+		watcher:      ui.NewFileWatcher(nil, ui.DefaultWatchInterval),
+		toasts:       ui.NewToasts(theme),
+	}
+
+	m, _ = m.applyConfigReload(configReloadedMsg{cfg: domain.Config{}})
+
+	if keys := m.list.KeyMap.Filter.Keys(); len(keys) != 1 || keys[0] != "ctrl+s" {
+		t.Fatalf("expected startup filter binding to survive reload, got %v", keys)
+	}
+	if !m.list.KeyMap.Filter.Enabled() {
+		t.Fatal("expected filter binding to stay enabled after reload")
+	}
+	if m.list.KeyMap.ClearFilter.Enabled() {
+		t.Fatal("expected clear filter binding to stay disabled while unfiltered")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/adapters/editor"
+	"github.com/go-cli-template/internal/workflow"
+)
+
+type searchOptions struct {
+	ignoreCase bool
+	hidden     bool
+	max        int
+	open       bool
+}
+
+func newSearchCmd() *cobra.Command {
+	opts := &searchOptions{}
+	cmd := &cobra.Command{
+		Use:   "search <pattern> [path...]",
+		Short: "Search file contents and open the matches",
+		Long:  searchHelp(),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(cmd, opts, args[0], args[1:])
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.ignoreCase, "ignore-case", "i", false, "match case-insensitively")
+	cmd.Flags().BoolVar(&opts.hidden, "hidden", false, "include hidden files and directories")
+	cmd.Flags().IntVarP(&opts.max, "max", "m", 500, "stop after this many matches (0 for no limit)")
+	cmd.Flags().BoolVarP(&opts.open, "open", "o", false, "open the matches in the editor")
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, opts *searchOptions, pattern string, paths []string) error {
+	matches, warnings, err := workflow.Search(pattern, paths, workflow.SearchOptions{
+		IgnoreCase: opts.ignoreCase,
+		Hidden:     opts.hidden,
+		MaxResults: opts.max,
+	})
+	for _, warning := range warnings {
+		printWarning(cmd, "%s", warning)
+	}
+	switch {
+	case errors.Is(err, workflow.ErrSearchLimit):
+		printWarning(cmd, "stopped after %d matches; raise --max to see more", opts.max)
+	case err != nil:
+		return err
+	}
+	if len(matches) == 0 {
+		cmd.PrintErrln("No matches")
+		return nil
+	}
+
+	locations := make([]editor.Location, 0, len(matches))
+	for _, match := range matches {
+		locations = append(locations, editor.Location{
+			Path:   match.Path,
+			Line:   match.Line,
+			Column: match.Column,
+			Text:   match.Text,
+		})
+	}
+	if !opts.open {
+		for _, location := range locations {
+			cmd.Println(location.String())
+		}
+		return nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	cfg := loadConfigOrDefault(cmd, newConfigManager(cmd, cwd))
+	if err := newEditor(cfg).OpenLocations(locations); err != nil {
+		return fmt.Errorf("failed to open matches: %w", err)
+	}
+	return nil
+}
+
+func searchHelp() string {
+	return strings.Join([]string{
+		"Search files for lines matching a regular expression.",
+		"",
+		"Matches are printed as path:line:col:text, which vim's quickfix list",
+		"and most editors understand. Paths default to the current directory;",
+		"binary files and version control directories are skipped. Files and",
+		"directories that cannot be read are skipped with a warning.",
+		"",
+		"With --open the matches are opened in the editor: vim loads them into",
+		"the quickfix list, VS Code, Helix, Zed and Sublime Text open every",
+		"match, and other editors open each matching file.",
+		"",
+		"Examples:",
+		"  go-cli-template search TODO",
+		"  go-cli-template search -i 'func \\w+Config' internal cmd",
+		"  go-cli-template search --open TODO",
+	}, "\n")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/testutil"
+)
+
+func TestSearchPrintsLocations(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+	if err := os.WriteFile("notes.txt", []byte("first\nsecond TODO\n"), 0o644); err != nil {
+		t.Fatalf("write notes: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "search", "TODO")
+	if err != nil {
+		t.Fatalf("search: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "notes.txt:2:8:second TODO") {
+		t.Errorf("expected a path:line:col:text match, got %q", out)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "search", "missing")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if !strings.Contains(out, "No matches") {
+		t.Errorf("expected no matches notice, got %q", out)
+	}
+}
@@ -0,0 +1,454 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/adapters/shell"
+	pkg "github.com/go-cli-template/internal/package"
+	"github.com/go-cli-template/internal/ui"
+	"github.com/go-cli-template/internal/utils"
+)
+
+func newShellCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Work with shell aliases and functions",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newShellImportCmd())
+	cmd.AddCommand(newShellInstallCmd())
+	cmd.AddCommand(newShellUninstallCmd())
+	return cmd
+}
+
+type shellImportOptions struct {
+	shell   string
+	against []string
+}
+
+func newShellImportCmd() *cobra.Command {
+	opts := &shellImportOptions{}
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "List the aliases and functions defined in a shell file",
+		Long:  shellImportHelp(),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShellImport(cmd, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&opts.shell, "shell", "s", "", "shell the file is written for (default: guessed from the file name)")
+	cmd.Flags().StringSliceVar(&opts.against, "against", nil, "also check for names already defined in these files")
+	_ = cmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return shell.Shells(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func runShellImport(cmd *cobra.Command, opts *shellImportOptions, path string) error {
+	adapter := shellAdapterForFile(opts.shell, path)
+	entries, err := adapter.ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	lists := [][]shell.Entry{entries}
+	for _, other := range opts.against {
+		// Other files say which shell they are for before --shell does
+		name := shell.ShellForPath(other)
+		if name == "" {
+			name = opts.shell
+		}
+		existing, err := shellAdapterForFile(name, other).ParseFile(other)
+		if err != nil {
+			return err
+		}
+		lists = append(lists, existing)
+	}
+
+	if len(entries) == 0 {
+		cmd.Printf("No aliases or functions found in %s\n", path)
+	} else {
+		cmd.Printf("Found %d %s in %s (%s):\n", len(entries), plural(len(entries), "entry", "entries"), path, adapter.Shell())
+		width := 0
+		for _, entry := range entries {
+			width = max(width, len(entry.Name))
+		}
+		for _, entry := range entries {
+			cmd.Printf("  %-*s  %-8s  %s\n", width, entry.Name, entry.Kind, summarizeCommand(entry.Command))
+		}
+	}
+
+	for _, collision := range shell.FindCollisions(lists...) {
+		locations := make([]string, len(collision.Entries))
+		for i, entry := range collision.Entries {
+			locations[i] = entry.Location()
+		}
+		printWarning(cmd, "%s is defined %d times: %s", collision.Name, len(locations), strings.Join(locations, ", "))
+	}
+	return nil
+}
+
+// shellAdapterForFile returns the adapter for the named shell, or for the
+// shell path is written in when no name is given. Files that do not say,
+// such as ".sh" files, are read as POSIX shell.
+func shellAdapterForFile(name, path string) *shell.Adapter {
+	if name == "" {
+		name = shell.ShellForPath(path)
+	}
+	if name == "" {
+		name = "sh"
+	}
+	return shell.New(name)
+}
+
+// summarizeCommand returns the first line of a command, noting how many
+// lines follow.
+func summarizeCommand(command string) string {
+	first, rest, found := strings.Cut(command, "\n")
+	if !found {
+		return first
+	}
+	more := strings.Count(rest, "\n") + 1
+	return fmt.Sprintf("%s … (+%d %s)", first, more, plural(more, "line", "lines"))
+}
+
+// plural returns singular when n is one and plural otherwise.
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+func shellImportHelp() string {
+	return strings.Join([]string{
+		"Read the aliases and functions defined in a bash, zsh, sh, ksh, fish",
+		"or nushell file and list them by name.",
+		"",
+		"The shell is guessed from the file name, such as .zshrc or a .fish",
+		"extension; other files are read as POSIX shell unless --shell is given.",
+		"Names defined more than once, in the file or in the files given with",
+		"--against, are reported so they can be resolved before writing.",
+		"",
+		"Examples:",
+		"  go-cli-template shell import ~/.bash_aliases",
+		"  go-cli-template shell import ~/.config/fish/config.fish",
+		"  go-cli-template shell import --shell zsh aliases.sh --against ~/.zshrc",
+	}, "\n")
+}
+
+type shellInstallOptions struct {
+	shell  string
+	rcFile string
+	dryRun bool
+	yes    bool
+}
+
+func newShellInstallCmd() *cobra.Command {
+	opts := &shellInstallOptions{}
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Load shell integration from your shell's rc file",
+		Long:  shellInstallHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShellInstall(cmd, opts)
+		},
+	}
+	addShellInstallFlags(cmd, opts)
+	return cmd
+}
+
+func newShellUninstallCmd() *cobra.Command {
+	opts := &shellInstallOptions{}
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the shell integration block from your shell's rc file",
+		Long:  shellUninstallHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShellUninstall(cmd, opts)
+		},
+	}
+	addShellInstallFlags(cmd, opts)
+	return cmd
+}
+
+func addShellInstallFlags(cmd *cobra.Command, opts *shellInstallOptions) {
+	cmd.Flags().StringVarP(&opts.shell, "shell", "s", "", "shell to set up (default: the current shell)")
+	cmd.Flags().StringVar(&opts.rcFile, "rc-file", "", "rc file to change (default: the shell's usual rc file)")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "show the changes without writing files")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "change the rc file without asking")
+	_ = cmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return shell.Shells(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func runShellInstall(cmd *cobra.Command, opts *shellInstallOptions) error {
+	adapter, rcPath, err := resolveShellInstall(opts)
+	if err != nil {
+		return err
+	}
+	program := cmd.Root().Name()
+	scriptPath := shellScriptPath(adapter)
+	script, err := renderShellScript(cmd.Root(), adapter)
+	if err != nil {
+		return err
+	}
+	before, err := readOptionalFile(rcPath)
+	if err != nil {
+		return err
+	}
+	after, err := adapter.InstallBlock(before, program, scriptPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rcPath, err)
+	}
+
+	if before == after {
+		if !opts.dryRun {
+			if err := writeShellScript(scriptPath, script); err != nil {
+				return err
+			}
+		}
+		cmd.Printf("%s already loads %s\n", rcPath, scriptPath)
+		return nil
+	}
+
+	cmd.Print(utils.UnifiedDiff(rcPath, rcPath, before, after))
+	if opts.dryRun {
+		cmd.Println("Dry run: no files were changed")
+		return nil
+	}
+	ok, err := confirmRCChange(cmd, opts, "Install shell integration", fmt.Sprintf("Add the block above to %s?", rcPath))
+	if err != nil || !ok {
+		return err
+	}
+
+	// The script must exist before the rc file loads it
+	if err := writeShellScript(scriptPath, script); err != nil {
+		return err
+	}
+	backup, err := writeRCFile(rcPath, before, after)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Wrote %s\n", scriptPath)
+	cmd.Printf("Updated %s\n", rcPath)
+	if backup != "" {
+		cmd.Printf("Backed up original to %s\n", backup)
+	}
+	cmd.Printf("Open a new shell, or run: %s\n", adapter.FormatSource(scriptPath))
+	return nil
+}
+
+func runShellUninstall(cmd *cobra.Command, opts *shellInstallOptions) error {
+	adapter, rcPath, err := resolveShellInstall(opts)
+	if err != nil {
+		return err
+	}
+	scriptPath := shellScriptPath(adapter)
+	before, err := readOptionalFile(rcPath)
+	if err != nil {
+		return err
+	}
+	after, found, err := shell.RemoveBlock(before, cmd.Root().Name())
+	if err != nil {
+		return fmt.Errorf("%s: %w", rcPath, err)
+	}
+
+	if found {
+		cmd.Print(utils.UnifiedDiff(rcPath, rcPath, before, after))
+	} else {
+		cmd.Printf("%s has no %s block\n", rcPath, cmd.Root().Name())
+	}
+	if opts.dryRun {
+		cmd.Println("Dry run: no files were changed")
+		return nil
+	}
+	if found {
+		ok, err := confirmRCChange(cmd, opts, "Uninstall shell integration", fmt.Sprintf("Remove the block above from %s?", rcPath))
+		if err != nil || !ok {
+			return err
+		}
+		backup, err := writeRCFile(rcPath, before, after)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Updated %s\n", rcPath)
+		if backup != "" {
+			cmd.Printf("Backed up original to %s\n", backup)
+		}
+	}
+	switch err := os.Remove(scriptPath); {
+	case err == nil:
+		cmd.Printf("Removed %s\n", scriptPath)
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	return nil
+}
+
+// resolveShellInstall returns the adapter and rc file for the --shell and
+// --rc-file flags, defaulting to the current shell and its rc file.
+func resolveShellInstall(opts *shellInstallOptions) (*shell.Adapter, string, error) {
+	name := opts.shell
+	if name == "" {
+		name = shell.DetectShell()
+	}
+	adapter := shell.New(strings.ToLower(name))
+	if opts.rcFile != "" {
+		return adapter, opts.rcFile, nil
+	}
+	rcPath, err := adapter.RCFile()
+	return adapter, rcPath, err
+}
+
+// shellScriptPath returns where the script the rc file block loads is
+// written, one per shell.
+func shellScriptPath(adapter *shell.Adapter) string {
+	return filepath.Join(utils.XDGDataHome(), pkg.Name(), "shell", adapter.Shell()+shell.GetFileExtension(adapter.Shell()))
+}
+
+// renderShellScript returns the script the rc file block loads, which
+// sets up completion where the shell supports it.
+func renderShellScript(root *cobra.Command, adapter *shell.Adapter) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Generated by %s shell install; changes are overwritten.\n", root.Name())
+	if err := writeCompletion(root, &buf, adapter); err != nil && !errors.Is(err, shell.ErrNoCompletion) {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeShellScript(path, script string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(script), 0o644)
+}
+
+// readOptionalFile returns the contents of path, or "" when it does not
+// exist yet.
+func readOptionalFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// confirmRCChange asks before an rc file is changed. Without a terminal
+// the change needs --yes, since the diff cannot be confirmed.
+func confirmRCChange(cmd *cobra.Command, opts *shellInstallOptions, title, prompt string) (bool, error) {
+	if opts.yes {
+		return true, nil
+	}
+	if !isInteractive() {
+		return false, errors.New("not changing the rc file without confirmation; re-run with --yes")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false, err
+	}
+	cfg := loadConfigOrDefault(cmd, newConfigManager(cmd, cwd))
+	ok, err := ui.PromptConfirmation(title, prompt, ui.ThemeFromConfig(cfg))
+	if err == nil && !ok {
+		cmd.Println("No changes made")
+	}
+	return ok, err
+}
+
+// writeRCFile replaces the contents of an rc file, keeping its mode. An
+// existing file is first copied to <file>.<timestamp>.bak, and the backup
+// path is returned.
+func writeRCFile(path, before, after string) (string, error) {
+	mode := os.FileMode(0o644)
+	backup := ""
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		backup = backupPath(path, time.Now())
+		if err := os.WriteFile(backup, []byte(before), mode); err != nil {
+			return "", err
+		}
+	} else if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return backup, writeFileAtomic(path, []byte(after), mode)
+}
+
+// writeFileAtomic writes data to a temporary file in path's directory and
+// renames it over path, so an interrupted write never leaves path partly
+// written. A symlinked path, as dotfile managers create, is followed so
+// the link is kept.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	defer os.Remove(tmp)
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// backupPath returns an unused <file>.<timestamp>.bak path, adding a
+// counter when a backup was already made in the same second.
+func backupPath(path string, now time.Time) string {
+	return unusedPath(fmt.Sprintf("%s.%s", path, now.Format("20060102-150405")), ".bak")
+}
+
+func shellInstallHelp() string {
+	return strings.Join([]string{
+		"Add a block to your shell's rc file that loads a script generated by",
+		"go-cli-template, which sets up completion for the current shell.",
+		"",
+		"The block is delimited by \"# >>> go-cli-template >>>\" and",
+		"\"# <<< go-cli-template <<<\" lines, so running install again updates it",
+		"in place. The script is written to $XDG_DATA_HOME/go-cli-template/shell.",
+		"A diff is shown before the rc file is changed, and the original is",
+		"backed up next to it as <file>.<timestamp>.bak.",
+		"",
+		"Supported rc files: ~/.bashrc, ~/.zshrc, ~/.kshrc, ~/.profile, fish's",
+		"config.fish, nushell's config.nu, the PowerShell profile, ~/.xonshrc,",
+		"elvish's rc.elv and ~/.tcshrc. Use --rc-file for any other file.",
+		"",
+		"Examples:",
+		"  go-cli-template shell install --dry-run",
+		"  go-cli-template shell install --shell fish",
+		"  go-cli-template shell install --shell zsh --rc-file ~/.config/zsh/.zshrc",
+	}, "\n")
+}
+
+func shellUninstallHelp() string {
+	return strings.Join([]string{
+		"Remove the block shell install added to your shell's rc file, and the",
+		"script it loaded. The rest of the rc file is left as it was. A diff is",
+		"shown first and the original is backed up as <file>.<timestamp>.bak.",
+		"",
+		"Examples:",
+		"  go-cli-template shell uninstall --dry-run",
+		"  go-cli-template shell uninstall --shell fish",
+	}, "\n")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/testutil"
+)
+
+func TestShellImport(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+	aliases := "# mine\nalias gs='git status'\nmkcd() {\n  mkdir -p \"$1\"\n  cd \"$1\"\n}\n"
+	if err := os.WriteFile("aliases.sh", []byte(aliases), 0o644); err != nil {
+		t.Fatalf("write aliases: %v", err)
+	}
+	if err := os.WriteFile("config.fish", []byte("alias gs 'git status --short'\n"), 0o644); err != nil {
+		t.Fatalf("write fish config: %v", err)
+	}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), "shell", "import", "aliases.sh")
+	if err != nil {
+		t.Fatalf("shell import: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Found 2 entries in aliases.sh (sh)", "gs    alias     git status", "mkcd  function  mkdir -p \"$1\" … (+1 line)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Warning") {
+		t.Errorf("expected no collisions, got:\n%s", out)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "shell", "import", "aliases.sh", "--against", "config.fish")
+	if err != nil {
+		t.Fatalf("shell import --against: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Warning: gs is defined 2 times: aliases.sh:2, config.fish:1") {
+		t.Errorf("expected a collision with the fish alias, got:\n%s", out)
+	}
+
+	if _, err := testutil.RunCLI(t, newRootCmd(), "shell", "import", "aliases.sh", "--shell", "elvish"); err == nil {
+		t.Error("expected an error for a shell without a parser")
+	}
+}
+
+func TestShellInstallAndUninstall(t *testing.T) {
+	_, dataDir, _ := testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+	interactive := isInteractive
+	isInteractive = func() bool { return false }
+	t.Cleanup(func() { isInteractive = interactive })
+	original := "export EDITOR=vim\n"
+	if err := os.WriteFile("bashrc", []byte(original), 0o600); err != nil {
+		t.Fatalf("write rc: %v", err)
+	}
+	script := filepath.Join(dataDir, "go-cli-template", "shell", "bash.sh")
+	args := []string{"shell", "install", "--shell", "bash", "--rc-file", "bashrc"}
+
+	out, err := testutil.RunCLI(t, newRootCmd(), append(args, "--dry-run")...)
+	if err != nil {
+		t.Fatalf("install --dry-run: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "+# >>> go-cli-template >>>") || pathExists(script) {
+		t.Errorf("expected a diff and no files written, got:\n%s", out)
+	}
+
+	if _, err := testutil.RunCLI(t, newRootCmd(), args...); err == nil {
+		t.Fatal("expected install to need --yes without a terminal")
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), append(args, "--yes")...)
+	if err != nil {
+		t.Fatalf("install: %v\n%s", err, out)
+	}
+	installed, _ := os.ReadFile("bashrc")
+	if !strings.Contains(string(installed), ". '"+script+"'") {
+		t.Errorf("expected the rc file to source %s, got:\n%s", script, installed)
+	}
+	if data, err := os.ReadFile(script); err != nil || !strings.Contains(string(data), "__start_go-cli-template") {
+		t.Errorf("expected the script to set up completion, got %v", err)
+	}
+	if info, _ := os.Stat("bashrc"); info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the rc file mode to be kept, got %v", info.Mode())
+	}
+	backups, _ := filepath.Glob("bashrc.*.bak")
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v", backups)
+	}
+	if data, _ := os.ReadFile(backups[0]); string(data) != original {
+		t.Errorf("expected the backup to hold the original, got %q", data)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), append(args, "--yes")...)
+	if err != nil || !strings.Contains(out, "already loads") {
+		t.Errorf("expected a second install to change nothing, got %v:\n%s", err, out)
+	}
+
+	out, err = testutil.RunCLI(t, newRootCmd(), "shell", "uninstall", "--shell", "bash", "--rc-file", "bashrc", "--yes")
+	if err != nil {
+		t.Fatalf("uninstall: %v\n%s", err, out)
+	}
+	if data, _ := os.ReadFile("bashrc"); string(data) != original {
+		t.Errorf("expected uninstall to restore the rc file, got %q", data)
+	}
+	if pathExists(script) {
+		t.Error("expected uninstall to remove the script")
+	}
+	if backups, _ := filepath.Glob("bashrc.*.bak"); len(backups) != 2 {
+		t.Errorf("expected a second backup, got %v", backups)
+	}
+}
+
+func TestShellInstallRCFileChecks(t *testing.T) {
+	testutil.WithTempXDG(t)
+	testutil.WithTempWorkspace(t)
+	args := []string{"shell", "install", "--shell", "bash", "--rc-file", "bashrc", "--yes"}
+
+	if err := os.WriteFile("bashrc", []byte("# >>> go-cli-template >>>\nexport A=1\n"), 0o644); err != nil {
+		t.Fatalf("write rc: %v", err)
+	}
+	out, err := testutil.RunCLI(t, newRootCmd(), args...)
+	if err == nil || !strings.Contains(err.Error(), "without a matching") {
+		t.Errorf("expected an error for an unclosed block, got %v:\n%s", err, out)
+	}
+
+	// A symlinked rc file is updated through the link
+	if err := os.WriteFile("dotfiles-bashrc", []byte("export A=1\n"), 0o644); err != nil {
+		t.Fatalf("write rc: %v", err)
+	}
+	if err := os.Remove("bashrc"); err != nil {
+		t.Fatalf("remove rc: %v", err)
+	}
+	if err := os.Symlink("dotfiles-bashrc", "bashrc"); err != nil {
+		t.Fatalf("symlink rc: %v", err)
+	}
+	if out, err := testutil.RunCLI(t, newRootCmd(), args...); err != nil {
+		t.Fatalf("install: %v\n%s", err, out)
+	}
+	if info, err := os.Lstat("bashrc"); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("expected bashrc to stay a symlink, got %v", err)
+	}
+	if data, _ := os.ReadFile("dotfiles-bashrc"); !strings.Contains(string(data), "# >>> go-cli-template >>>") {
+		t.Errorf("expected the link target to be updated, got:\n%s", data)
+	}
+}
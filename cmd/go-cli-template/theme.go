@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/go-cli-template/internal/config"
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/ui"
+)
+
+func newThemeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "List and preview color themes",
+		Long:  themeHelp(),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeList(cmd)
+		},
+	}
+	cmd.AddCommand(newThemeListCmd())
+	cmd.AddCommand(newThemePreviewCmd())
+	return cmd
+}
+
+func newThemeListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List built-in and user themes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeList(cmd)
+		},
+	}
+}
+
+func newThemePreviewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "preview [name...]",
+		Short: "Render sample list items with a theme",
+		Long: strings.Join([]string{
+			"Render sample list items with one or more themes.",
+			"",
+			"Without a name, the colors from the current config are shown,",
+			"including any individual color keys set on top of the theme.",
+		}, "\n"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemePreview(cmd, args)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			themes, err := config.Themes()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			names := make([]string, 0, len(themes))
+			for _, theme := range themes {
+				names = append(names, theme.Name)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+}
+
+func runThemeList(cmd *cobra.Command) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	cfg := loadConfigOrDefault(cmd, newConfigManager(cmd, cwd))
+	themes, err := config.Themes()
+	if err != nil {
+		return err
+	}
+	for _, theme := range themes {
+		marker := " "
+		if theme.Name == cfg.Theme {
+			marker = "*"
+		}
+		source := "built-in"
+		if !theme.BuiltIn() {
+			source = theme.Path
+		}
+		cmd.Printf("%s %-18s %s\n", marker, theme.Name, source)
+	}
+	return nil
+}
+
+func runThemePreview(cmd *cobra.Command, names []string) error {
+	if len(names) == 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		cfg := loadConfigOrDefault(cmd, newConfigManager(cmd, cwd))
+		cmd.Println(renderThemePreview(cfg.Theme+" (current config)", ui.ThemeFromConfig(cfg)))
+		return nil
+	}
+
+	previews := make([]string, 0, len(names))
+	for _, name := range names {
+		cfg := domain.DefaultConfig()
+		if _, err := config.ApplyTheme(&cfg, name); err != nil {
+			return err
+		}
+		previews = append(previews, renderThemePreview(name, ui.ThemeFromConfig(cfg)))
+	}
+	cmd.Println(strings.Join(previews, "\n\n"))
+	return nil
+}
+
+func renderThemePreview(name string, theme ui.Theme) string {
+	title := lipgloss.NewStyle().Foreground(theme.Headings).Bold(true).Render(name)
+	return title + "\n" + ui.ThemePreview(theme)
+}
+
+func themeHelp() string {
+	return strings.Join([]string{
+		"List and preview color themes.",
+		"",
+		"Select a theme with `theme = \"<name>\"` in config. Theme files are read",
+		"from " + config.ThemesDir() + " and may either set the color keys",
+		"directly or contain a Base16/Base24 palette (base00..base0F, base10..base17).",
+		"Individual color keys in config still override the theme.",
+		"",
+		"Examples:",
+		"  go-cli-template theme list",
+		"  go-cli-template theme preview catppuccin-mocha nord",
+	}, "\n")
+}
@@ -14,3 +14,9 @@ func (Adapter) WriteText(text string) error {
 func (Adapter) ReadText() (string, error) {
 	return atotto.ReadAll()
 }
+
+// Available reports whether a clipboard backend was found. On Linux this
+// requires xclip, xsel, wl-clipboard or Termux.
+func (Adapter) Available() bool {
+	return !atotto.Unsupported
+}
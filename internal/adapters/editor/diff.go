@@ -0,0 +1,94 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoDiffMode is returned when neither the diff tool nor the editor can
+// compare two files. Callers can fall back to utils.UnifiedDiff.
+var ErrNoDiffMode = errors.New("editor has no diff mode")
+
+// diffTools lists standalone diff and merge tools that take the two files
+// as arguments.
+var diffTools = []string{"meld", "kdiff3", "kompare", "diffuse", "opendiff", "bcompare"}
+
+// IsDiffTool checks if the command is a standalone diff tool such as meld.
+func IsDiffTool(command string) bool {
+	base := strings.TrimSuffix(getEditorBase(command), ".exe")
+	for _, tool := range diffTools {
+		if base == tool {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDiffTool sets the command Diff uses instead of the editor, such as
+// "meld". An empty tool uses the editor's own diff mode.
+func (a *Adapter) WithDiffTool(tool string) *Adapter {
+	a.DiffTool = strings.TrimSpace(tool)
+	return a
+}
+
+// Diff compares two files side by side in the diff tool, or in the editor
+// when no tool is set. It returns ErrNoDiffMode when the editor cannot.
+func (a Adapter) Diff(left, right string) error {
+	cmd, err := a.DiffCmd(left, right)
+	if err != nil {
+		return err
+	}
+	return run(cmd)
+}
+
+// DiffCmd returns the command Diff runs without starting it.
+func (a Adapter) DiffCmd(left, right string) (*exec.Cmd, error) {
+	var argv []string
+	var err error
+	if a.DiffTool != "" {
+		argv, err = DiffArgs(a.DiffTool, left, right)
+		if errors.Is(err, ErrNoDiffMode) {
+			// A configured tool is trusted to take the two files
+			argv, err = SplitCommand(a.DiffTool)
+			argv = append(argv, left, right)
+		}
+	} else {
+		argv, err = DiffArgs(a.command(), left, right)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(argv[0], argv[1:]...), nil
+}
+
+// DiffArgs returns the argv that compares left and right in command:
+// vim's -d, VS Code's --diff, emacs ediff, a JetBrains IDE's diff command
+// or a standalone tool such as meld. Other editors yield ErrNoDiffMode.
+func DiffArgs(command, left, right string) ([]string, error) {
+	argv, err := SplitCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case IsVim(command):
+		return append(argv, "-d", left, right), nil
+	case IsVSCode(command):
+		return append(argv, "--diff", left, right), nil
+	case IsEmacs(command):
+		return append(argv, "--eval", fmt.Sprintf("(ediff-files %s %s)", elispString(left), elispString(right))), nil
+	case IsJetBrains(command):
+		return append(argv, "diff", left, right), nil
+	case IsDiffTool(command):
+		return append(argv, left, right), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoDiffMode, getEditorBase(command))
+}
+
+// elispString quotes s as an Emacs Lisp string literal.
+func elispString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
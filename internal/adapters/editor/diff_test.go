@@ -0,0 +1,74 @@
+package editor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{"nvim", "nvim", []string{"nvim", "-d", "a.txt", "b.txt"}},
+		{"vscode", "code --wait", []string{"code", "--wait", "--diff", "a.txt", "b.txt"}},
+		{"emacs", "emacs -nw", []string{"emacs", "-nw", "--eval", `(ediff-files "a.txt" "b.txt")`}},
+		{"jetbrains", "goland", []string{"goland", "diff", "a.txt", "b.txt"}},
+		{"meld", "meld", []string{"meld", "a.txt", "b.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffArgs(tt.command, "a.txt", "b.txt")
+			if err != nil {
+				t.Fatalf("DiffArgs: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffArgs(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DiffArgs("nano", "a.txt", "b.txt"); !errors.Is(err, ErrNoDiffMode) {
+		t.Errorf("expected ErrNoDiffMode for nano, got %v", err)
+	}
+}
+
+func TestDiffArgsQuotesElisp(t *testing.T) {
+	got, err := DiffArgs("emacs", `my "notes".txt`, `C:\tmp\b.txt`)
+	if err != nil {
+		t.Fatalf("DiffArgs: %v", err)
+	}
+	want := `(ediff-files "my \"notes\".txt" "C:\\tmp\\b.txt")`
+	if got[len(got)-1] != want {
+		t.Errorf("got %q, want %q", got[len(got)-1], want)
+	}
+}
+
+func TestAdapterDiffCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter *Adapter
+		want    []string
+	}{
+		{"editor diff mode", New("code"), []string{"code", "--wait", "--diff", "a.txt", "b.txt"}},
+		{"diff tool wins", New("nano").WithDiffTool("meld"), []string{"meld", "a.txt", "b.txt"}},
+		{"unknown diff tool", New("nano").WithDiffTool("my-diff --side-by-side"), []string{"my-diff", "--side-by-side", "a.txt", "b.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := tt.adapter.DiffCmd("a.txt", "b.txt")
+			if err != nil {
+				t.Fatalf("DiffCmd: %v", err)
+			}
+			if !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("DiffCmd().Args = %q, want %q", cmd.Args, tt.want)
+			}
+		})
+	}
+
+	if _, err := New("nano").DiffCmd("a.txt", "b.txt"); !errors.Is(err, ErrNoDiffMode) {
+		t.Errorf("expected ErrNoDiffMode without a diff mode, got %v", err)
+	}
+}
@@ -6,62 +6,258 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/go-cli-template/internal/utils"
+)
+
+// Template placeholders substituted in LineTemplate.
+const (
+	PlaceholderFile   = "{file}"
+	PlaceholderLine   = "{line}"
+	PlaceholderColumn = "{col}"
+)
+
+// Window layouts used when vim opens several files at once.
+const (
+	LayoutTabs   = "tabs"
+	LayoutVSplit = "vsplit"
+	LayoutSplit  = "split"
 )
 
+// Layouts lists the accepted layouts.
+var Layouts = []string{LayoutTabs, LayoutVSplit, LayoutSplit}
+
+var vimLayoutFlags = map[string]string{
+	LayoutTabs:   "-p",
+	LayoutVSplit: "-O",
+	LayoutSplit:  "-o",
+}
+
 // Adapter launches the configured editor.
 type Adapter struct {
 	Command string
+	// LineTemplate, when set, replaces the built-in position syntax, e.g.
+	// "hx {file}:{line}:{col}".
+	LineTemplate string
+	// Layout arranges files opened together in vim; see Layouts.
+	Layout string
+	// Wait adds the wait flag of GUI editors so opening a file blocks
+	// until it is closed; see WaitCommand.
+	Wait bool
+	// DiffTool, when set, is used by Diff instead of the editor.
+	DiffTool string
+}
+
+// Location is a position in a file, such as a content search hit. Line
+// and Column are 1-based; zero means unknown.
+type Location struct {
+	Path   string
+	Line   int
+	Column int
+	Text   string
+}
+
+// String formats the location as path:line:col:text, the format vim's
+// quickfix list and most grep tools understand.
+func (l Location) String() string {
+	text := strings.ReplaceAll(l.Text, "\n", " ")
+	return fmt.Sprintf("%s:%d:%d:%s", l.Path, max(l.Line, 1), max(l.Column, 1), text)
 }
 
 // New returns an editor adapter using the given command.
 func New(command string) *Adapter {
-	return &Adapter{Command: command}
+	return &Adapter{Command: command, Wait: true}
+}
+
+// WithLineTemplate sets the command template used to open files at a
+// position. An empty template keeps the built-in editor detection.
+func (a *Adapter) WithLineTemplate(template string) *Adapter {
+	a.LineTemplate = strings.TrimSpace(template)
+	return a
+}
+
+// WithLayout sets how vim arranges several files: tabs, vsplit or split.
+// Unknown layouts fall back to tabs.
+func (a *Adapter) WithLayout(layout string) *Adapter {
+	a.Layout = strings.TrimSpace(layout)
+	return a
+}
+
+// WithWait sets whether GUI editors are started with their wait flag.
+func (a *Adapter) WithWait(wait bool) *Adapter {
+	a.Wait = wait
+	return a
 }
 
 // Open launches the editor with the provided file path.
 func (a Adapter) Open(path string) error {
-	command := ResolveCommand(a.Command)
-	if command == "" {
-		return errors.New("editor command is required")
-	}
-	return runEditorCommand(command, []string{path})
+	return a.OpenAt(path, 0, 0)
 }
 
 // OpenAtLine opens a file at a specific line number.
 func (a Adapter) OpenAtLine(path string, line int) error {
-	command := ResolveCommand(a.Command)
-	if command == "" {
-		return errors.New("editor command is required")
+	return a.OpenAt(path, line, 0)
+}
+
+// OpenAt opens a file with the cursor at line and column, both 1-based.
+// A column of zero or less places the cursor at the start of the line,
+// and a line of zero or less opens the file without a position. The
+// LineTemplate is used when set; otherwise editors without a known
+// position syntax open the file normally.
+func (a Adapter) OpenAt(path string, line, col int) error {
+	argv, err := a.argsAt(path, line, col)
+	if err != nil {
+		return err
 	}
+	return runEditorCommand(argv[0], argv[1:])
+}
 
-	if IsVim(command) {
-		return OpenVimAtLine(command, path, line)
+// OpenMany opens several files in one editor session. Vim opens them in
+// tabs or splits following Layout and VS Code reuses the current window;
+// other editors receive the paths as arguments.
+func (a Adapter) OpenMany(paths []string) error {
+	cmd, err := a.OpenManyCmd(paths)
+	if err != nil {
+		return err
+	}
+	return run(cmd)
+}
+
+// OpenManyCmd returns the command OpenMany runs without starting it, for
+// callers such as tea.ExecProcess that manage the terminal themselves.
+func (a Adapter) OpenManyCmd(paths []string) (*exec.Cmd, error) {
+	argv, err := ManyArgs(a.command(), a.Layout, paths)
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(argv[0], argv[1:]...), nil
+}
+
+// OpenLocations opens a set of positions, such as search results. A
+// single location opens like OpenAt. Vim loads several into its quickfix
+// list, editors that accept path:line:col arguments receive every
+// position, and other editors open each file once.
+func (a Adapter) OpenLocations(locations []Location) error {
+	cmd, cleanup, err := a.OpenLocationsCmd(locations)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return run(cmd)
+}
+
+// OpenLocationsCmd returns the command OpenLocations runs without starting
+// it. cleanup removes the temporary quickfix file, if any, and must be
+// called once the editor exits.
+func (a Adapter) OpenLocationsCmd(locations []Location) (cmd *exec.Cmd, cleanup func(), err error) {
+	cleanup = func() {}
+	if len(locations) == 0 {
+		return nil, cleanup, errors.New("no locations to open")
 	}
-	if IsNano(command) {
-		return OpenNanoAtLine(command, path, line)
+
+	var argv []string
+	command := a.command()
+	switch {
+	case len(locations) == 1:
+		argv, err = a.argsAt(locations[0].Path, locations[0].Line, locations[0].Column)
+	case IsVim(command):
+		var quickfix string
+		quickfix, err = writeQuickfix(locations)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() { _ = os.Remove(quickfix) }
+		argv, err = LocationArgs(command, a.Layout, locations, quickfix)
+	default:
+		argv, err = LocationArgs(command, a.Layout, locations, "")
 	}
-	if IsVSCode(command) {
-		return OpenVSCodeAtLine(command, path, line)
+	if err != nil {
+		cleanup()
+		return nil, func() {}, err
 	}
-	if IsEmacs(command) {
-		return OpenEmacsAtLine(command, path, line)
+	return exec.Command(argv[0], argv[1:]...), cleanup, nil
+}
+
+// command returns the resolved editor command, with the wait flag added
+// when Wait is set.
+func (a Adapter) command() string {
+	command := ResolveCommand(a.Command)
+	if a.Wait {
+		command = WaitCommand(command)
 	}
+	return command
+}
 
-	// For unknown editors, just open normally
-	return a.Open(path)
+// argsAt returns the argv OpenAt runs.
+func (a Adapter) argsAt(path string, line, col int) ([]string, error) {
+	if a.LineTemplate != "" && line > 0 {
+		return TemplateArgs(a.LineTemplate, path, line, col)
+	}
+	return PositionArgs(a.command(), path, line, col)
+}
+
+// TemplateArgs splits template into words like a shell would, without
+// running one, and substitutes {file}, {line} and {col} in each word. A
+// path containing spaces or quotes therefore stays a single argument. A
+// missing column is substituted as 1, and the path is appended when the
+// template has no {file} placeholder.
+func TemplateArgs(template, path string, line, col int) ([]string, error) {
+	words, err := utils.SplitWords(template)
+	if err != nil {
+		return nil, fmt.Errorf("editor template %q: %w", template, err)
+	}
+	if len(words) == 0 {
+		return nil, errors.New("editor template is empty")
+	}
+	replacer := strings.NewReplacer(
+		PlaceholderFile, path,
+		PlaceholderLine, strconv.Itoa(max(line, 1)),
+		PlaceholderColumn, strconv.Itoa(max(col, 1)),
+	)
+	hasFile := false
+	argv := make([]string, 0, len(words)+1)
+	for _, word := range words {
+		if strings.Contains(word, PlaceholderFile) {
+			hasFile = true
+		}
+		argv = append(argv, replacer.Replace(word))
+	}
+	if !hasFile {
+		argv = append(argv, path)
+	}
+	return argv, nil
 }
 
 // OpenAtEnd opens a file and positions the cursor at the end when supported.
 func (a Adapter) OpenAtEnd(path string) error {
-	command := ResolveCommand(a.Command)
+	cmd, err := a.OpenAtEndCmd(path)
+	if err != nil {
+		return err
+	}
+	return run(cmd)
+}
+
+// OpenAtEndCmd returns the command OpenAtEnd runs without starting it.
+func (a Adapter) OpenAtEndCmd(path string) (*exec.Cmd, error) {
+	command := a.command()
 	if command == "" {
-		return errors.New("editor command is required")
+		return nil, errors.New("editor command is required")
 	}
+	var argv []string
+	var err error
 	if IsVim(command) {
-		return OpenVimAtEnd(command, path)
+		argv, err = SplitCommand(command)
+		argv = append(argv, vimEndArgs(path)...)
+	} else {
+		argv, err = a.argsAt(path, 0, 0)
 	}
-	return a.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(argv[0], argv[1:]...), nil
 }
 
 // ResolveCommand resolves the editor command from config or environment.
@@ -78,17 +274,34 @@ func ResolveCommand(command string) string {
 
 // runEditorCommand executes an editor command with the given arguments.
 func runEditorCommand(command string, args []string) error {
-	cmd := exec.Command(command, args...)
+	return run(exec.Command(command, args...))
+}
+
+// run executes cmd attached to the terminal.
+func run(cmd *exec.Cmd) error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 	return cmd.Run()
 }
 
+// SplitCommand splits an editor command into its program and arguments
+// using shell quoting rules, so `"/path with spaces/code" --wait` works.
+func SplitCommand(command string) ([]string, error) {
+	words, err := utils.SplitWords(command)
+	if err != nil {
+		return nil, fmt.Errorf("editor command %q: %w", command, err)
+	}
+	if len(words) == 0 {
+		return nil, errors.New("editor command is required")
+	}
+	return words, nil
+}
+
 // getEditorBase extracts the base command name from a command string.
 func getEditorBase(command string) string {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
+	fields, err := SplitCommand(command)
+	if err != nil {
 		return ""
 	}
 	return strings.ToLower(filepath.Base(fields[0]))
@@ -130,62 +343,342 @@ func IsEmacs(command string) bool {
 	return strings.Contains(base, "emacs")
 }
 
+// IsHelix checks if the command is helix.
+func IsHelix(command string) bool {
+	base := getEditorBase(command)
+	return base == "hx" || base == "helix"
+}
+
+// IsKakoune checks if the command is kakoune.
+func IsKakoune(command string) bool {
+	return getEditorBase(command) == "kak"
+}
+
+// IsMicro checks if the command is micro.
+func IsMicro(command string) bool {
+	return getEditorBase(command) == "micro"
+}
+
+// IsZed checks if the command is the zed CLI.
+func IsZed(command string) bool {
+	base := getEditorBase(command)
+	return base == "zed" || base == "zeditor"
+}
+
+// IsSublime checks if the command is sublime text.
+func IsSublime(command string) bool {
+	base := getEditorBase(command)
+	return base == "subl" || base == "sublime_text"
+}
+
+// IsGVim checks if the command is a graphical vim, which forks into the
+// background unless told otherwise.
+func IsGVim(command string) bool {
+	base := getEditorBase(command)
+	return base == "gvim" || base == "mvim" || base == "gview"
+}
+
+// jetBrainsLaunchers lists the command-line launchers of JetBrains IDEs.
+var jetBrainsLaunchers = []string{
+	"idea", "goland", "pycharm", "webstorm", "phpstorm", "rubymine",
+	"clion", "rider", "datagrip", "dataspell", "rustrover", "studio",
+}
+
+// IsJetBrains checks if the command launches a JetBrains IDE, including
+// the idea.sh and idea64.exe style launchers.
+func IsJetBrains(command string) bool {
+	base := getEditorBase(command)
+	base = strings.TrimSuffix(base, ".exe")
+	base = strings.TrimSuffix(base, ".sh")
+	base = strings.TrimSuffix(base, ".cmd")
+	base = strings.TrimSuffix(base, "64")
+	for _, launcher := range jetBrainsLaunchers {
+		if base == launcher {
+			return true
+		}
+	}
+	return false
+}
+
+// waitFlags maps GUI editors, which return as soon as the file is handed
+// to a window, to the flags that make them block until it is closed. The
+// first flag is the one added; the rest are accepted spellings.
+var waitFlags = []struct {
+	match func(command string) bool
+	flags []string
+}{
+	{IsVSCode, []string{"--wait", "-w"}},
+	{IsZed, []string{"--wait", "-w"}},
+	{IsSublime, []string{"--wait", "-w"}},
+	{IsJetBrains, []string{"--wait"}},
+	{IsGVim, []string{"--nofork", "-f"}},
+	{func(command string) bool { return getEditorBase(command) == "kate" }, []string{"--block", "-b"}},
+	{func(command string) bool { return getEditorBase(command) == "mate" }, []string{"--wait", "-w"}},
+}
+
+// IsGUI reports whether command is a GUI editor known to return before the
+// file is closed.
+func IsGUI(command string) bool {
+	return WaitFlag(command) != ""
+}
+
+// WaitFlag returns the flag that makes a GUI editor wait for the file to
+// be closed, or "" for editors that already block.
+func WaitFlag(command string) string {
+	for _, wait := range waitFlags {
+		if wait.match(command) {
+			return wait.flags[0]
+		}
+	}
+	return ""
+}
+
+// WaitCommand adds the wait flag to a GUI editor command unless it already
+// has one, so `code` becomes `code --wait`. Other commands are returned
+// unchanged.
+func WaitCommand(command string) string {
+	for _, wait := range waitFlags {
+		if !wait.match(command) {
+			continue
+		}
+		fields, err := SplitCommand(command)
+		if err != nil {
+			return command
+		}
+		for _, field := range fields[1:] {
+			if slices.Contains(wait.flags, field) {
+				return command
+			}
+		}
+		return command + " " + wait.flags[0]
+	}
+	return command
+}
+
+// positionFormats maps editor families to the arguments that open path at
+// a position. line is at least 1; col is 0 when no column was given.
+var positionFormats = []struct {
+	match func(command string) bool
+	args  func(path string, line, col int) []string
+}{
+	{IsVim, vimPositionArgs},
+	{IsNano, plusPositionArgs(",")},
+	{IsVSCode, func(path string, line, col int) []string {
+		return []string{"-g", suffixPosition(path, line, col)}
+	}},
+	{IsEmacs, plusPositionArgs(":")},
+	{IsHelix, suffixPositionArgs},
+	{IsKakoune, plusPositionArgs(":")},
+	{IsMicro, plusPositionArgs(":")},
+	{IsZed, suffixPositionArgs},
+	{IsSublime, suffixPositionArgs},
+	{IsJetBrains, func(path string, line, col int) []string {
+		args := []string{"--line", strconv.Itoa(line)}
+		if col > 0 {
+			args = append(args, "--column", strconv.Itoa(col))
+		}
+		return append(args, path)
+	}},
+}
+
+// PositionArgs returns the argv that opens path in command at line and
+// column. Positions are left out for editors without a known syntax and
+// when line is zero or less.
+func PositionArgs(command, path string, line, col int) ([]string, error) {
+	argv, err := SplitCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	if line <= 0 {
+		return append(argv, path), nil
+	}
+	col = max(col, 0)
+	for _, format := range positionFormats {
+		if format.match(command) {
+			return append(argv, format.args(path, line, col)...), nil
+		}
+	}
+	return append(argv, path), nil
+}
+
+// ManyArgs returns the argv that opens paths together in command.
+func ManyArgs(command, layout string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no files to open")
+	}
+	argv, err := SplitCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case IsVim(command) && len(paths) > 1:
+		flag, ok := vimLayoutFlags[layout]
+		if !ok {
+			flag = vimLayoutFlags[LayoutTabs]
+		}
+		argv = append(argv, flag)
+	case IsVSCode(command):
+		argv = withVSCodeReuse(argv)
+	}
+	return append(argv, paths...), nil
+}
+
+// LocationArgs returns the argv that opens locations in command. Vim is
+// pointed at quickfix, a file in the format written by FormatQuickfix.
+// Editors without a way to open several positions get the unique paths.
+func LocationArgs(command, layout string, locations []Location, quickfix string) ([]string, error) {
+	if len(locations) == 0 {
+		return nil, errors.New("no locations to open")
+	}
+	argv, err := SplitCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case IsVim(command):
+		return append(argv, "-q", quickfix), nil
+	case IsVSCode(command):
+		argv = append(withVSCodeReuse(argv), "-g")
+		for _, location := range locations {
+			argv = append(argv, locationSuffix(location))
+		}
+		return argv, nil
+	case IsHelix(command), IsZed(command), IsSublime(command):
+		for _, location := range locations {
+			argv = append(argv, locationSuffix(location))
+		}
+		return argv, nil
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, location := range locations {
+		if !seen[location.Path] {
+			seen[location.Path] = true
+			paths = append(paths, location.Path)
+		}
+	}
+	return ManyArgs(command, layout, paths)
+}
+
+// FormatQuickfix renders locations as a vim quickfix list, one
+// path:line:col:text entry per line.
+func FormatQuickfix(locations []Location) string {
+	var builder strings.Builder
+	for _, location := range locations {
+		builder.WriteString(location.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// writeQuickfix writes locations to a temporary quickfix file.
+func writeQuickfix(locations []Location) (string, error) {
+	file, err := os.CreateTemp("", "quickfix-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create quickfix file: %w", err)
+	}
+	if _, err := file.WriteString(FormatQuickfix(locations)); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write quickfix file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write quickfix file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// withVSCodeReuse adds --reuse-window unless the command already picks a
+// window.
+func withVSCodeReuse(argv []string) []string {
+	for _, arg := range argv[1:] {
+		switch arg {
+		case "-r", "--reuse-window", "-n", "--new-window":
+			return argv
+		}
+	}
+	return append(argv, "--reuse-window")
+}
+
+func locationSuffix(location Location) string {
+	if location.Line <= 0 {
+		return location.Path
+	}
+	return suffixPosition(location.Path, location.Line, max(location.Column, 0))
+}
+
+// vimPositionArgs uses +N, or cursor() when a column is given.
+func vimPositionArgs(path string, line, col int) []string {
+	if col > 0 {
+		return []string{fmt.Sprintf("+call cursor(%d,%d)", line, col), path}
+	}
+	return []string{fmt.Sprintf("+%d", line), path}
+}
+
+// plusPositionArgs builds +line[<sep>col] followed by the path.
+func plusPositionArgs(sep string) func(path string, line, col int) []string {
+	return func(path string, line, col int) []string {
+		position := fmt.Sprintf("+%d", line)
+		if col > 0 {
+			position += fmt.Sprintf("%s%d", sep, col)
+		}
+		return []string{position, path}
+	}
+}
+
+// suffixPositionArgs passes the path as path:line[:col].
+func suffixPositionArgs(path string, line, col int) []string {
+	return []string{suffixPosition(path, line, col)}
+}
+
+func suffixPosition(path string, line, col int) string {
+	if col > 0 {
+		return fmt.Sprintf("%s:%d:%d", path, line, col)
+	}
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
 // OpenVimInsert opens vim at a specific line in insert mode.
 func OpenVimInsert(command, path string, line int) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
-	}
-	args := append(fields[1:], fmt.Sprintf("+call cursor(%d,1)", line), "+startinsert", path)
-	return runEditorCommand(fields[0], args)
+	return openWithArgs(command, []string{fmt.Sprintf("+call cursor(%d,1)", line), "+startinsert", path})
 }
 
 // OpenVimAtLine opens vim at a specific line.
 func OpenVimAtLine(command, path string, line int) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
-	}
-	args := append(fields[1:], fmt.Sprintf("+%d", line), path)
-	return runEditorCommand(fields[0], args)
+	return openWithArgs(command, vimPositionArgs(path, line, 0))
 }
 
 // OpenVimAtEnd opens vim at the end of the file.
 func OpenVimAtEnd(command, path string) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
-	}
-	args := append(fields[1:], "+normal G$", path)
-	return runEditorCommand(fields[0], args)
+	return openWithArgs(command, vimEndArgs(path))
+}
+
+func vimEndArgs(path string) []string {
+	return []string{"+normal G$", path}
 }
 
 // OpenNanoAtLine opens nano at a specific line.
 func OpenNanoAtLine(command, path string, line int) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
-	}
-	args := append(fields[1:], fmt.Sprintf("+%d", line), path)
-	return runEditorCommand(fields[0], args)
+	return openWithArgs(command, plusPositionArgs(",")(path, line, 0))
 }
 
 // OpenVSCodeAtLine opens VSCode at a specific line.
 func OpenVSCodeAtLine(command, path string, line int) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
-	}
-	args := append(fields[1:], "-g", fmt.Sprintf("%s:%d", path, line))
-	return runEditorCommand(fields[0], args)
+	return openWithArgs(command, []string{"-g", suffixPosition(path, line, 0)})
 }
 
 // OpenEmacsAtLine opens emacs at a specific line.
 func OpenEmacsAtLine(command, path string, line int) error {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return errors.New("editor command is required")
+	return openWithArgs(command, plusPositionArgs(":")(path, line, 0))
+}
+
+// openWithArgs runs command with its own arguments followed by args.
+func openWithArgs(command string, args []string) error {
+	fields, err := SplitCommand(command)
+	if err != nil {
+		return err
 	}
-	args := append(fields[1:], fmt.Sprintf("+%d", line), path)
-	return runEditorCommand(fields[0], args)
+	return runEditorCommand(fields[0], append(fields[1:], args...))
 }
@@ -0,0 +1,283 @@
+package editor
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestPositionArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		line    int
+		col     int
+		want    []string
+	}{
+		{"vim line", "vim", 12, 0, []string{"vim", "+12", "main.go"}},
+		{"nvim column", "nvim", 12, 5, []string{"nvim", "+call cursor(12,5)", "main.go"}},
+		{"vim with flags", "/usr/bin/vim -u NONE", 3, 0, []string{"/usr/bin/vim", "-u", "NONE", "+3", "main.go"}},
+		{"nano line", "nano", 12, 0, []string{"nano", "+12", "main.go"}},
+		{"nano column", "nano", 12, 5, []string{"nano", "+12,5", "main.go"}},
+		{"vscode line", "code", 12, 0, []string{"code", "-g", "main.go:12"}},
+		{"vscode column", "code --wait", 12, 5, []string{"code", "--wait", "-g", "main.go:12:5"}},
+		{"cursor column", "cursor", 12, 5, []string{"cursor", "-g", "main.go:12:5"}},
+		{"emacs line", "emacs -nw", 12, 0, []string{"emacs", "-nw", "+12", "main.go"}},
+		{"emacsclient column", "emacsclient -t", 12, 5, []string{"emacsclient", "-t", "+12:5", "main.go"}},
+		{"helix line", "hx", 12, 0, []string{"hx", "main.go:12"}},
+		{"helix column", "helix", 12, 5, []string{"helix", "main.go:12:5"}},
+		{"kakoune line", "kak", 12, 0, []string{"kak", "+12", "main.go"}},
+		{"kakoune column", "kak", 12, 5, []string{"kak", "+12:5", "main.go"}},
+		{"micro line", "micro", 12, 0, []string{"micro", "+12", "main.go"}},
+		{"micro column", "micro", 12, 5, []string{"micro", "+12:5", "main.go"}},
+		{"zed line", "zed", 12, 0, []string{"zed", "main.go:12"}},
+		{"zed column", "zeditor --wait", 12, 5, []string{"zeditor", "--wait", "main.go:12:5"}},
+		{"sublime line", "subl", 12, 0, []string{"subl", "main.go:12"}},
+		{"sublime column", "subl -w", 12, 5, []string{"subl", "-w", "main.go:12:5"}},
+		{"jetbrains line", "idea", 12, 0, []string{"idea", "--line", "12", "main.go"}},
+		{"jetbrains column", "goland", 12, 5, []string{"goland", "--line", "12", "--column", "5", "main.go"}},
+		{"jetbrains script", "/opt/pycharm/bin/pycharm.sh", 12, 0, []string{"/opt/pycharm/bin/pycharm.sh", "--line", "12", "main.go"}},
+		{"jetbrains windows", "idea64.exe", 12, 5, []string{"idea64.exe", "--line", "12", "--column", "5", "main.go"}},
+		{"unknown editor", "ed", 12, 5, []string{"ed", "main.go"}},
+		{"no line", "hx", 0, 5, []string{"hx", "main.go"}},
+		{"negative column", "hx", 12, -1, []string{"hx", "main.go:12"}},
+		{"quoted command", `"/opt/My Editor/bin/nvim" -u NONE`, 3, 0, []string{"/opt/My Editor/bin/nvim", "-u", "NONE", "+3", "main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PositionArgs(tt.command, "main.go", tt.line, tt.col)
+			if err != nil {
+				t.Fatalf("PositionArgs: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PositionArgs(%q, %d, %d) = %q, want %q", tt.command, tt.line, tt.col, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionArgsRequiresCommand(t *testing.T) {
+	if _, err := PositionArgs("  ", "main.go", 1, 1); err == nil {
+		t.Error("expected an error for an empty command")
+	}
+}
+
+func TestTemplateArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		line     int
+		col      int
+		want     []string
+	}{
+		{"placeholders", "hx {file}:{line}:{col}", "main.go", 12, 5, []string{"hx", "main.go:12:5"}},
+		{"missing column", "hx {file}:{line}:{col}", "main.go", 12, 0, []string{"hx", "main.go:12:1"}},
+		{"path with spaces", "edit --goto {file}:{line}", "/tmp/my notes.md", 3, 0, []string{"edit", "--goto", "/tmp/my notes.md:3"}},
+		{"path with quotes", "edit {file}", `it's "here".txt`, 3, 0, []string{"edit", `it's "here".txt`}},
+		{"no shell expansion", "edit {file}", "$(rm -rf ~);x", 3, 0, []string{"edit", "$(rm -rf ~);x"}},
+		{"quoted program", `'/opt/My Editor/edit' -l {line}`, "main.go", 7, 0, []string{"/opt/My Editor/edit", "-l", "7", "main.go"}},
+		{"escaped space", `my\ editor +{line} {file}`, "main.go", 7, 0, []string{"my editor", "+7", "main.go"}},
+		{"no file placeholder", "kak +{line}:{col}", "main.go", 2, 3, []string{"kak", "+2:3", "main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateArgs(tt.template, tt.path, tt.line, tt.col)
+			if err != nil {
+				t.Fatalf("TemplateArgs: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TemplateArgs(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+
+	for _, template := range []string{"", "  ", `hx "{file}`} {
+		if _, err := TemplateArgs(template, "main.go", 1, 1); err == nil {
+			t.Errorf("TemplateArgs(%q): expected an error", template)
+		}
+	}
+}
+
+func TestAdapterArgsAt(t *testing.T) {
+	templated := New("nvim").WithLineTemplate("hx {file}:{line}:{col}")
+	got, err := templated.argsAt("main.go", 4, 2)
+	if err != nil || !reflect.DeepEqual(got, []string{"hx", "main.go:4:2"}) {
+		t.Errorf("expected the template to be used, got %q (%v)", got, err)
+	}
+
+	// Without a line there is no position to substitute
+	got, err = templated.argsAt("main.go", 0, 0)
+	if err != nil || !reflect.DeepEqual(got, []string{"nvim", "main.go"}) {
+		t.Errorf("expected the editor command without a line, got %q (%v)", got, err)
+	}
+
+	got, err = New("nvim").argsAt("main.go", 4, 0)
+	if err != nil || !reflect.DeepEqual(got, []string{"nvim", "+4", "main.go"}) {
+		t.Errorf("expected built-in detection without a template, got %q (%v)", got, err)
+	}
+}
+
+func TestEditorDetection(t *testing.T) {
+	tests := []struct {
+		command string
+		detect  func(string) bool
+		want    bool
+	}{
+		{"hx", IsHelix, true},
+		{"/usr/local/bin/helix", IsHelix, true},
+		{"hexedit", IsHelix, false},
+		{"kak", IsKakoune, true},
+		{"kakoune-lsp", IsKakoune, false},
+		{"micro", IsMicro, true},
+		{"zed", IsZed, true},
+		{"subl", IsSublime, true},
+		{"webstorm64.exe", IsJetBrains, true},
+		{"rustrover", IsJetBrains, true},
+		{"ideas", IsJetBrains, false},
+	}
+	for _, tt := range tests {
+		if got := tt.detect(tt.command); got != tt.want {
+			t.Errorf("detect(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestManyArgs(t *testing.T) {
+	paths := []string{"a.go", "b.go"}
+	tests := []struct {
+		name    string
+		command string
+		layout  string
+		paths   []string
+		want    []string
+	}{
+		{"vim tabs", "vim", LayoutTabs, paths, []string{"vim", "-p", "a.go", "b.go"}},
+		{"nvim vsplit", "nvim", LayoutVSplit, paths, []string{"nvim", "-O", "a.go", "b.go"}},
+		{"vim split", "vim", LayoutSplit, paths, []string{"vim", "-o", "a.go", "b.go"}},
+		{"vim unknown layout", "vim", "grid", paths, []string{"vim", "-p", "a.go", "b.go"}},
+		{"vim single file", "vim", LayoutTabs, []string{"a.go"}, []string{"vim", "a.go"}},
+		{"vscode reuses window", "code --wait", "", paths, []string{"code", "--wait", "--reuse-window", "a.go", "b.go"}},
+		{"vscode keeps new window", "code -n", "", paths, []string{"code", "-n", "a.go", "b.go"}},
+		{"other editors", "hx", "", paths, []string{"hx", "a.go", "b.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ManyArgs(tt.command, tt.layout, tt.paths)
+			if err != nil {
+				t.Fatalf("ManyArgs: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ManyArgs(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ManyArgs("vim", LayoutTabs, nil); err == nil {
+		t.Error("expected an error without paths")
+	}
+}
+
+func TestLocationArgs(t *testing.T) {
+	locations := []Location{
+		{Path: "a.go", Line: 3, Column: 2, Text: "TODO one"},
+		{Path: "a.go", Line: 9, Text: "TODO two"},
+		{Path: "b.go", Line: 1, Column: 1, Text: "TODO three"},
+	}
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{"vim quickfix", "nvim", []string{"nvim", "-q", "/tmp/qf.txt"}},
+		{"vscode goto", "code", []string{"code", "--reuse-window", "-g", "a.go:3:2", "a.go:9", "b.go:1:1"}},
+		{"helix positions", "hx", []string{"hx", "a.go:3:2", "a.go:9", "b.go:1:1"}},
+		{"other editors get unique paths", "nano", []string{"nano", "a.go", "b.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LocationArgs(tt.command, LayoutTabs, locations, "/tmp/qf.txt")
+			if err != nil {
+				t.Fatalf("LocationArgs: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LocationArgs(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatQuickfix(t *testing.T) {
+	got := FormatQuickfix([]Location{
+		{Path: "a.go", Line: 3, Column: 2, Text: "func main() {"},
+		{Path: "b.go", Text: "line\nbreak"},
+	})
+	want := "a.go:3:2:func main() {\nb.go:1:1:line break\n"
+	if got != want {
+		t.Errorf("FormatQuickfix() = %q, want %q", got, want)
+	}
+}
+
+func TestOpenLocationsCmdWritesQuickfix(t *testing.T) {
+	adapter := New("vim")
+	cmd, cleanup, err := adapter.OpenLocationsCmd([]Location{
+		{Path: "a.go", Line: 1, Text: "one"},
+		{Path: "b.go", Line: 2, Text: "two"},
+	})
+	if err != nil {
+		t.Fatalf("OpenLocationsCmd: %v", err)
+	}
+	if len(cmd.Args) != 3 || cmd.Args[1] != "-q" {
+		t.Fatalf("expected vim -q <file>, got %q", cmd.Args)
+	}
+	data, err := os.ReadFile(cmd.Args[2])
+	if err != nil {
+		t.Fatalf("read quickfix: %v", err)
+	}
+	if string(data) != "a.go:1:1:one\nb.go:2:1:two\n" {
+		t.Errorf("quickfix file = %q", data)
+	}
+	cleanup()
+	if _, err := os.Stat(cmd.Args[2]); !os.IsNotExist(err) {
+		t.Errorf("expected cleanup to remove the quickfix file, got %v", err)
+	}
+}
+
+func TestWaitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"code", "code --wait"},
+		{"code -w", "code -w"},
+		{"code --wait", "code --wait"},
+		{"subl", "subl --wait"},
+		{"zed", "zed --wait"},
+		{"goland", "goland --wait"},
+		{"gvim", "gvim --nofork"},
+		{"mvim -f", "mvim -f"},
+		{"kate", "kate --block"},
+		{`"/Applications/Visual Studio Code.app/bin/code"`, `"/Applications/Visual Studio Code.app/bin/code" --wait`},
+		{"nvim", "nvim"},
+		{"emacsclient -t", "emacsclient -t"},
+	}
+	for _, tt := range tests {
+		if got := WaitCommand(tt.command); got != tt.want {
+			t.Errorf("WaitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+	if !IsGUI("code") || IsGUI("vim") {
+		t.Error("expected code to be a GUI editor and vim not to be")
+	}
+}
+
+func TestAdapterWait(t *testing.T) {
+	got, err := New("code").argsAt("main.go", 3, 0)
+	if err != nil || !reflect.DeepEqual(got, []string{"code", "--wait", "-g", "main.go:3"}) {
+		t.Errorf("expected the wait flag by default, got %q (%v)", got, err)
+	}
+	got, err = New("code").WithWait(false).argsAt("main.go", 3, 0)
+	if err != nil || !reflect.DeepEqual(got, []string{"code", "-g", "main.go:3"}) {
+		t.Errorf("expected no wait flag when disabled, got %q (%v)", got, err)
+	}
+}
@@ -0,0 +1,166 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultCommentPrefix starts the lines EditSession removes from the
+// result, like the help text in a git commit message.
+const DefaultCommentPrefix = "#"
+
+// EditOptions configures an EditSession.
+type EditOptions struct {
+	// Extension is given to the temp file so editors pick the right
+	// syntax, e.g. ".md". A missing leading dot is added.
+	Extension string
+	// CommentPrefix starts lines that are removed from the result. It
+	// defaults to DefaultCommentPrefix.
+	CommentPrefix string
+	// KeepComments returns comment lines as written.
+	KeepComments bool
+}
+
+// EditResult is the text captured by an EditSession.
+type EditResult struct {
+	// Text is the edited content with comment lines removed and trailing
+	// whitespace trimmed.
+	Text string
+	// Aborted is set when the user left the text unchanged or emptied it.
+	Aborted bool
+}
+
+// EditSession captures text from the user's editor through a temporary
+// file, the way git asks for a commit message. The file is seeded with
+// content, opened with the cursor at the end, and read back once the
+// editor exits.
+//
+// From a command, EditText does all of this in one call. From a Bubble Tea
+// program, hand the session's command to tea.ExecProcess so the program
+// releases the terminal while the editor runs:
+//
+//	session, err := adapter.NewEditSession(seed, editor.EditOptions{Extension: ".md"})
+//	if err != nil {
+//	    return m, reportError(err)
+//	}
+//	cmd, err := session.Cmd()
+//	if err != nil {
+//	    session.Cleanup()
+//	    return m, reportError(err)
+//	}
+//	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+//	    result, finishErr := session.Finish()
+//	    return editedMsg{result: result, err: errors.Join(err, finishErr)}
+//	})
+type EditSession struct {
+	adapter Adapter
+	path    string
+	seed    string
+	opts    EditOptions
+}
+
+// NewEditSession writes seed to a new temporary file. Call Finish, or
+// Cleanup if the editor never runs, to remove it.
+func (a Adapter) NewEditSession(seed string, opts EditOptions) (*EditSession, error) {
+	if opts.CommentPrefix == "" {
+		opts.CommentPrefix = DefaultCommentPrefix
+	}
+	ext := opts.Extension
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	file, err := os.CreateTemp("", "edit-*"+ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	_, err = file.WriteString(seed)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return &EditSession{adapter: a, path: file.Name(), seed: seed, opts: opts}, nil
+}
+
+// EditText opens seed in the editor and returns what the user wrote. The
+// temporary file is removed before returning.
+func (a Adapter) EditText(seed string, opts EditOptions) (EditResult, error) {
+	session, err := a.NewEditSession(seed, opts)
+	if err != nil {
+		return EditResult{}, err
+	}
+	cmd, err := session.Cmd()
+	if err != nil {
+		session.Cleanup()
+		return EditResult{}, err
+	}
+	if err := run(cmd); err != nil {
+		session.Cleanup()
+		return EditResult{}, fmt.Errorf("editor failed: %w", err)
+	}
+	return session.Finish()
+}
+
+// Path returns the temporary file being edited.
+func (s *EditSession) Path() string {
+	return s.path
+}
+
+// Cmd returns the editor command for the temporary file, with the cursor
+// placed at the end when the editor supports it. It does not start it.
+func (s *EditSession) Cmd() (*exec.Cmd, error) {
+	return s.adapter.OpenAtEndCmd(s.path)
+}
+
+// Finish reads the edited file, removes it and returns the result. The
+// edit counts as aborted when the text, ignoring comments and surrounding
+// whitespace, is empty or the same as the seed.
+func (s *EditSession) Finish() (EditResult, error) {
+	defer s.Cleanup()
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Deleting the file is another way to abort
+			return EditResult{Aborted: true}, nil
+		}
+		return EditResult{}, fmt.Errorf("failed to read temp file: %w", err)
+	}
+	text := s.clean(string(data))
+	aborted := strings.TrimSpace(text) == "" ||
+		strings.TrimSpace(text) == strings.TrimSpace(s.clean(s.seed))
+	return EditResult{Text: text, Aborted: aborted}, nil
+}
+
+// Cleanup removes the temporary file. It is safe to call more than once.
+func (s *EditSession) Cleanup() {
+	_ = os.Remove(s.path)
+}
+
+// clean removes comment lines unless they are kept, normalizes line
+// endings and trims trailing whitespace.
+func (s *EditSession) clean(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if !s.opts.KeepComments {
+		text = StripComments(text, s.opts.CommentPrefix)
+	}
+	return strings.TrimRight(text, " \t\n")
+}
+
+// StripComments removes the lines of text that start with prefix, ignoring
+// leading whitespace.
+func StripComments(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), prefix) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,104 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writer returns an editor command that replaces the edited file with
+// content.
+func writer(content string) string {
+	return "sh -c 'printf \"%s\" \"$0\" > \"$1\"' '" + content + "'"
+}
+
+func TestEditText(t *testing.T) {
+	seed := "\n# Write a message above.\n# Lines starting with # are ignored.\n"
+	tests := []struct {
+		name    string
+		command string
+		opts    EditOptions
+		want    EditResult
+	}{
+		{
+			name:    "strips comments",
+			command: writer("hello\n  # note\nworld\n\n"),
+			want:    EditResult{Text: "hello\nworld"},
+		},
+		{
+			name:    "keeps comments",
+			command: writer("hello\n# note\n"),
+			opts:    EditOptions{KeepComments: true},
+			want:    EditResult{Text: "hello\n# note"},
+		},
+		{
+			name:    "custom comment prefix",
+			command: writer("hello\n// note\n"),
+			opts:    EditOptions{CommentPrefix: "//"},
+			want:    EditResult{Text: "hello"},
+		},
+		{
+			name:    "unchanged seed aborts",
+			command: "true",
+			want:    EditResult{Aborted: true},
+		},
+		{
+			name:    "only comments aborts",
+			command: writer("# nothing\n"),
+			want:    EditResult{Aborted: true},
+		},
+		{
+			name:    "deleted file aborts",
+			command: "rm",
+			want:    EditResult{Aborted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.command).EditText(seed, tt.opts)
+			if err != nil {
+				t.Fatalf("EditText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EditText() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditSession(t *testing.T) {
+	session, err := New("vim").NewEditSession("seed\n", EditOptions{Extension: "md"})
+	if err != nil {
+		t.Fatalf("NewEditSession: %v", err)
+	}
+	if filepath.Ext(session.Path()) != ".md" {
+		t.Errorf("expected a .md temp file, got %s", session.Path())
+	}
+	data, err := os.ReadFile(session.Path())
+	if err != nil || string(data) != "seed\n" {
+		t.Fatalf("expected the seed to be written, got %q (%v)", data, err)
+	}
+
+	cmd, err := session.Cmd()
+	if err != nil {
+		t.Fatalf("Cmd: %v", err)
+	}
+	want := []string{"vim", "+normal G$", session.Path()}
+	if len(cmd.Args) != len(want) || cmd.Args[1] != want[1] || cmd.Args[2] != want[2] {
+		t.Errorf("Cmd().Args = %q, want %q", cmd.Args, want)
+	}
+
+	if err := os.WriteFile(session.Path(), []byte("seed\nmore\r\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	result, err := session.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if result.Aborted || result.Text != "seed\nmore" {
+		t.Errorf("Finish() = %+v", result)
+	}
+	if _, err := os.Stat(session.Path()); !os.IsNotExist(err) {
+		t.Errorf("expected Finish to remove the temp file, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoCompletion is returned for shells without programmable completion,
+// and for shells whose scripts cobra generates itself.
+var ErrNoCompletion = errors.New("no completion script for this shell")
+
+// CompletionScript returns a completion script for program in shells
+// cobra has no generator for: nushell, xonsh, elvish and tcsh. Each one
+// asks the program's hidden __complete command for candidates, which
+// prints one per line, optionally followed by a tab and a description,
+// and ends with a ":<directive>" line.
+func (a *Adapter) CompletionScript(program string) (string, error) {
+	var script string
+	switch a.shellType {
+	case "nu":
+		script = nushellCompletion
+	case "xonsh":
+		script = xonshCompletion
+	case "elvish":
+		script = elvishCompletion
+	case "tcsh", "csh":
+		script = tcshCompletion
+	default:
+		return "", fmt.Errorf("%w: %s", ErrNoCompletion, a.shellType)
+	}
+	replacer := strings.NewReplacer(
+		"{{program}}", program,
+		"{{identifier}}", strings.NewReplacer("-", "_", ".", "_").Replace(program),
+	)
+	return replacer.Replace(script), nil
+}
+
+// nushellCompletion installs an external completer for the program and
+// hands every other command to the completer that was set before.
+const nushellCompletion = `# {{program}} completions for nushell
+let {{identifier}}_completer = {|spans|
+    ^{{program}} __complete ...($spans | skip 1)
+    | lines
+    | where {|line| not ($line | str starts-with ":") }
+    | each {|line|
+        let parts = ($line | split row "\t")
+        {value: $parts.0, description: ($parts | get -o 1 | default "")}
+    }
+}
+
+let {{identifier}}_previous = $env.config.completions.external.completer?
+$env.config.completions.external.enable = true
+$env.config.completions.external.completer = {|spans|
+    if $spans.0 == "{{program}}" {
+        do ${{identifier}}_completer $spans
+    } else if ${{identifier}}_previous != null {
+        do ${{identifier}}_previous $spans
+    }
+}
+`
+
+const xonshCompletion = `# {{program}} completions for xonsh
+import subprocess
+
+from xonsh.completers.tools import RichCompletion, contextual_command_completer_for
+
+
+@contextual_command_completer_for("{{program}}")
+def _{{identifier}}_completer(command):
+    args = [arg.value for arg in command.args[1:command.arg_index]]
+    result = subprocess.run(
+        ["{{program}}", "__complete", *args, command.prefix],
+        capture_output=True,
+        text=True,
+    )
+    candidates = set()
+    for line in result.stdout.splitlines():
+        if not line or line.startswith(":"):
+            continue
+        value, _, description = line.partition("\t")
+        candidates.add(RichCompletion(value, description=description))
+    return candidates
+
+
+completer add {{identifier}} _{{identifier}}_completer "start"
+`
+
+const elvishCompletion = `# {{program}} completions for elvish
+use str
+
+set edit:completion:arg-completer[{{program}}] = {|@words|
+    {{program}} __complete $@words[1..] | from-lines | each {|line|
+        if (not (str:has-prefix $line ':')) {
+            var parts = [(str:split "\t" $line)]
+            edit:complex-candidate $parts[0]
+        }
+    }
+}
+`
+
+// tcshCompletion has sh split $COMMAND_LINE, which tcsh sets for the
+// completing command, and adds an empty word when a new one is started.
+const tcshCompletion = `# {{program}} completions for tcsh
+complete {{program}} 'p,*,` + "`" + `sh -c '"'"'set -- $COMMAND_LINE; shift; case "$COMMAND_LINE" in *" ") set -- "$@" "";; esac; {{program}} __complete "$@" 2>/dev/null | cut -f1 | grep -v "^:"'"'"'` + "`" + `,'
+`
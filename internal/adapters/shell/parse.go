@@ -0,0 +1,588 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-cli-template/internal/utils"
+)
+
+// ErrNoParser is returned when aliases cannot be read back for a shell.
+var ErrNoParser = errors.New("cannot parse aliases for this shell")
+
+// Entry kinds.
+const (
+	KindAlias    = "alias"
+	KindFunction = "function"
+)
+
+// Entry is an alias or function read from a shell file.
+type Entry struct {
+	Name    string
+	Command string
+	Kind    string
+	// Path is the file the entry was read from, when parsed with ParseFile.
+	Path string
+	// Line is the 1-based line the entry starts on.
+	Line int
+}
+
+// Location returns where the entry was defined, as path:line.
+func (e Entry) Location() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d", e.Line)
+	}
+	return fmt.Sprintf("%s:%d", e.Path, e.Line)
+}
+
+// Collision is a name defined by more than one entry.
+type Collision struct {
+	Name    string
+	Entries []Entry
+}
+
+// FindCollisions returns the names defined more than once across the
+// entry lists, in the order they were first defined.
+func FindCollisions(lists ...[]Entry) []Collision {
+	byName := make(map[string][]Entry)
+	var order []string
+	for _, entries := range lists {
+		for _, entry := range entries {
+			if _, ok := byName[entry.Name]; !ok {
+				order = append(order, entry.Name)
+			}
+			byName[entry.Name] = append(byName[entry.Name], entry)
+		}
+	}
+	var collisions []Collision
+	for _, name := range order {
+		if entries := byName[name]; len(entries) > 1 {
+			collisions = append(collisions, Collision{Name: name, Entries: entries})
+		}
+	}
+	return collisions
+}
+
+// ShellForPath guesses the shell a file is written for from its name,
+// such as ".fish" files or ".zshrc". Returns "" when the name says
+// nothing, as with plain ".sh" files.
+func ShellForPath(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	switch base {
+	case ".bashrc", ".bash_profile", ".bash_aliases", ".bash_login":
+		return "bash"
+	case ".zshrc", ".zshenv", ".zprofile", ".zlogin":
+		return "zsh"
+	case ".kshrc", ".mkshrc":
+		return "ksh"
+	case ".tcshrc", ".cshrc", ".login":
+		return "tcsh"
+	case ".xonshrc":
+		return "xonsh"
+	}
+	switch filepath.Ext(base) {
+	case ".bash":
+		return "bash"
+	case ".zsh":
+		return "zsh"
+	case ".ksh":
+		return "ksh"
+	case ".fish":
+		return "fish"
+	case ".nu":
+		return "nu"
+	case ".ps1":
+		return "pwsh"
+	case ".xsh":
+		return "xonsh"
+	case ".elv":
+		return "elvish"
+	case ".csh", ".tcsh":
+		return "tcsh"
+	}
+	return ""
+}
+
+// ParseFile reads the aliases and functions defined in a file.
+func (a *Adapter) ParseFile(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := a.Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range entries {
+		entries[i].Path = path
+	}
+	return entries, nil
+}
+
+// Parse reads the aliases and functions defined in a bash, zsh, sh, ksh,
+// fish or nushell file, in the order they appear. Comments and other
+// statements are skipped. Function bodies are returned with their common
+// indentation removed, and the argument forwarding FormatAlias adds is
+// dropped, so formatted aliases parse back to the command they were
+// made from.
+func (a *Adapter) Parse(content string) ([]Entry, error) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	switch a.shellType {
+	case "fish":
+		return parseFish(lines)
+	case "nu":
+		return parseNushell(lines)
+	case "bash", "zsh", "sh", "dash", "ksh":
+		return parsePosix(lines)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrNoParser, a.shellType)
+	}
+}
+
+var (
+	posixFunctionPattern = regexp.MustCompile(`^\s*(?:function\s+([\w.:@+-]+)\s*(?:\(\s*\))?|([\w.:@+-]+)\s*\(\s*\))\s*(\{.*)?$`)
+	fishFunctionPattern  = regexp.MustCompile(`^\s*function\s+(\S+)`)
+	fishBlockPattern     = regexp.MustCompile(`^(function|if|for|while|switch|begin)\b`)
+	nushellAliasPattern  = regexp.MustCompile(`^\s*(?:export\s+)?alias\s+("[^"]*"|'[^']*'|[^\s=]+)\s*=\s*(.*)$`)
+	nushellDefPattern    = regexp.MustCompile(`^\s*(?:export\s+)?def\s+(?:--[\w-]+\s+)*("[^"]*"|'[^']*'|\S+)\s*\[`)
+)
+
+// parsePosix reads alias statements and functions from a POSIX shell file.
+func parsePosix(lines []string) ([]Entry, error) {
+	var entries []Entry
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "alias "):
+			aliases, end, err := parsePosixAlias(lines, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			entries = append(entries, aliases...)
+			i = end
+		default:
+			match := posixFunctionPattern.FindStringSubmatch(lines[i])
+			if match == nil {
+				continue
+			}
+			name := match[1] + match[2]
+			opening := match[3]
+			start := i
+			// The brace may open on the next line
+			if opening == "" && i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "{") {
+				i++
+				opening = strings.TrimSpace(lines[i])
+			}
+			if opening == "" {
+				continue
+			}
+			body, end, err := braceBody(lines, i, opening)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: function %s: %w", start+1, name, err)
+			}
+			entries = append(entries, Entry{Name: name, Command: body, Kind: KindFunction, Line: start + 1})
+			i = end
+		}
+	}
+	return entries, nil
+}
+
+// parsePosixAlias reads the alias statement starting at lines[start],
+// which may define several aliases and continue over several lines inside
+// quotes. Comments are dropped, and further alias statements after a ";"
+// are read too. It returns the aliases and the last line of the statement.
+func parsePosixAlias(lines []string, start int) ([]Entry, int, error) {
+	text := lines[start]
+	end := start
+	statements, open := splitPosixStatements(text)
+	for open && end+1 < len(lines) {
+		end++
+		text += "\n" + lines[end]
+		statements, open = splitPosixStatements(text)
+	}
+	if open {
+		return nil, end, errors.New("unterminated quote")
+	}
+
+	var entries []Entry
+	for _, statement := range statements {
+		words, err := utils.SplitWords(statement)
+		if err != nil {
+			return nil, end, err
+		}
+		if len(words) == 0 || words[0] != "alias" {
+			continue
+		}
+		for _, word := range words[1:] {
+			// Skip options such as zsh's -g and the -- that ends them
+			if strings.HasPrefix(word, "-") {
+				continue
+			}
+			name, command, ok := strings.Cut(word, "=")
+			if !ok || name == "" {
+				continue
+			}
+			entries = append(entries, Entry{Name: name, Command: command, Kind: KindAlias, Line: start + 1})
+		}
+	}
+	return entries, end, nil
+}
+
+// splitPosixStatements splits text on unquoted semicolons and drops
+// comments, following the quoting rules of utils.SplitWords. open reports
+// that text ends inside a quote, so the statement continues on the next
+// line.
+func splitPosixStatements(text string) (statements []string, open bool) {
+	var (
+		quote    byte
+		trailing bool
+		current  strings.Builder
+	)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			}
+		case quote == '"':
+			if c == '\\' && i+1 < len(text) {
+				current.WriteByte(c)
+				i++
+				c = text[i]
+			} else if c == '"' {
+				quote = 0
+			}
+		case c == '\\':
+			if i+1 == len(text) {
+				trailing = true
+				break
+			}
+			current.WriteByte(c)
+			i++
+			c = text[i]
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || strings.ContainsRune(" \t\n;", rune(text[i-1]))):
+			// The comment runs to the end of the line
+			for i+1 < len(text) && text[i+1] != '\n' {
+				i++
+			}
+			continue
+		case c == ';':
+			statements = append(statements, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	statements = append(statements, current.String())
+	return statements, quote != 0 || trailing
+}
+
+// parseFish reads alias statements and functions from a fish file.
+func parseFish(lines []string) ([]Entry, error) {
+	var entries []Entry
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "alias "):
+			words, err := splitFishWords(trimmed)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			entry := Entry{Kind: KindAlias, Line: i + 1}
+			// fish takes both "alias name command" and "alias name=command"
+			args := words[1:]
+			if len(args) > 0 && strings.HasPrefix(args[0], "--") {
+				args = args[1:]
+			}
+			switch {
+			case len(args) == 1:
+				entry.Name, entry.Command, _ = strings.Cut(args[0], "=")
+			case len(args) > 1:
+				entry.Name, entry.Command = args[0], strings.Join(args[1:], " ")
+			}
+			if entry.Name != "" && entry.Command != "" {
+				entries = append(entries, entry)
+			}
+		case fishFunctionPattern.MatchString(lines[i]):
+			name := fishFunctionPattern.FindStringSubmatch(lines[i])[1]
+			start := i
+			depth := 1
+			var body []string
+			for i++; i < len(lines); i++ {
+				depth += fishBlockDelta(lines[i])
+				if depth <= 0 {
+					break
+				}
+				body = append(body, lines[i])
+			}
+			if depth > 0 {
+				return nil, fmt.Errorf("line %d: function %s: missing end", start+1, name)
+			}
+			command := dedent(body)
+			// Wrapping functions, as fish's alias writes them, pass $argv on
+			if !strings.Contains(command, "\n") {
+				command = strings.TrimSuffix(command, " $argv")
+			}
+			entries = append(entries, Entry{Name: name, Command: command, Kind: KindFunction, Line: start + 1})
+		}
+	}
+	return entries, nil
+}
+
+// fishBlockDelta returns how a line changes the fish block depth: each
+// statement opening a block adds one and each end removes one.
+func fishBlockDelta(line string) int {
+	delta := 0
+	for _, statement := range strings.Split(stripComment(line), ";") {
+		statement = strings.TrimSpace(statement)
+		switch {
+		case statement == "end" || strings.HasPrefix(statement, "end "):
+			delta--
+		case fishBlockPattern.MatchString(statement):
+			delta++
+		}
+	}
+	return delta
+}
+
+// parseNushell reads alias statements and defs from a nushell file.
+func parseNushell(lines []string) ([]Entry, error) {
+	var entries []Entry
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if match := nushellAliasPattern.FindStringSubmatch(lines[i]); match != nil {
+			entries = append(entries, Entry{
+				Name:    unquoteName(match[1]),
+				Command: strings.TrimSpace(stripComment(match[2])),
+				Kind:    KindAlias,
+				Line:    i + 1,
+			})
+			continue
+		}
+		match := nushellDefPattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		name := unquoteName(match[1])
+		start := i
+		// The body opens after the parameter list, which may span lines
+		opening := -1
+		for ; i < len(lines); i++ {
+			from := 0
+			if i == start {
+				from = strings.Index(lines[i], "[")
+			}
+			if close := strings.Index(lines[i][from:], "]"); close >= 0 {
+				if brace := strings.Index(lines[i][from+close:], "{"); brace >= 0 {
+					opening = from + close + brace
+				}
+				break
+			}
+		}
+		if opening < 0 {
+			return nil, fmt.Errorf("line %d: def %s: missing body", start+1, name)
+		}
+		body, end, err := braceBody(lines, i, lines[i][opening:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: def %s: %w", start+1, name, err)
+		}
+		entries = append(entries, Entry{Name: name, Command: body, Kind: KindFunction, Line: start + 1})
+		i = end
+	}
+	return entries, nil
+}
+
+// braceBody reads a block that opens with the "{" starting opening, found
+// on lines[start], up to its matching "}". Braces inside quotes and
+// comments are ignored. It returns the dedented body and the line the
+// block closes on.
+func braceBody(lines []string, start int, opening string) (string, int, error) {
+	text := opening[1:]
+	var body []string
+	depth := 1
+	for i := start; i < len(lines); i++ {
+		if i > start {
+			text = lines[i]
+		}
+		if close := closingBrace(text, &depth); close >= 0 {
+			if last := strings.TrimSpace(text[:close]); last != "" {
+				body = append(body, text[:close])
+			}
+			command := dedent(body)
+			// One-line bodies such as { ls; } end with a separator
+			if !strings.Contains(command, "\n") {
+				command = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(command), ";"))
+			}
+			return command, i, nil
+		}
+		if i > start || strings.TrimSpace(text) != "" {
+			body = append(body, text)
+		}
+	}
+	return "", len(lines), errors.New("missing closing brace")
+}
+
+// closingBrace scans text for braces outside quotes and comments, updating
+// depth, and returns the index of the brace that closes the block, or -1.
+func closingBrace(text string, depth *int) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\':
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return -1
+		case c == '{':
+			*depth++
+		case c == '}':
+			*depth--
+			if *depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// stripComment removes a trailing # comment that is outside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\':
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// dedent joins lines after removing their common leading whitespace.
+func dedent(lines []string) string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unquoteName removes the quotes around a nushell alias or def name.
+func unquoteName(name string) string {
+	if len(name) >= 2 && (name[0] == '"' || name[0] == '\'') && name[len(name)-1] == name[0] {
+		return name[1 : len(name)-1]
+	}
+	return name
+}
+
+// splitFishWords splits a fish command line into words. Inside single
+// quotes only \' and \\ are escapes, inside double quotes \", \\, \$ and a
+// backslash before a newline; elsewhere a backslash escapes the next
+// character. A # starting a word begins a comment.
+func splitFishWords(line string) ([]string, error) {
+	var (
+		words  []string
+		word   strings.Builder
+		inWord bool
+		quote  byte
+	)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '\'':
+			if c == '\\' && i+1 < len(line) && (line[i+1] == '\'' || line[i+1] == '\\') {
+				i++
+				word.WriteByte(line[i])
+			} else if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case quote == '"':
+			if c == '\\' && i+1 < len(line) && strings.IndexByte("\"\\$\n", line[i+1]) >= 0 {
+				i++
+				word.WriteByte(line[i])
+			} else if c == '"' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case c == '\\':
+			if i+1 < len(line) {
+				i++
+				word.WriteByte(line[i])
+			}
+			inWord = true
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '#' && !inWord:
+			return words, nil
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,214 @@
+package shell
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseFormattedAliases(t *testing.T) {
+	posixMulti := "cd ..\nif true; then\n\tls\nfi"
+	fishMulti := "cd ..\nif true\n\tls\nend"
+	nuMulti := "cd ..\nif true {\n\tls\n}"
+	tests := map[string]string{
+		"bash": posixMulti,
+		"zsh":  posixMulti,
+		"fish": fishMulti,
+		"nu":   nuMulti,
+	}
+	for shellType, multi := range tests {
+		t.Run(shellType, func(t *testing.T) {
+			commands := []Entry{
+				{Name: "gs", Command: "git status"},
+				{Name: "quote", Command: `echo "it's #1 at $HOME"`},
+				{Name: "brace", Command: "echo '{' \"}\""},
+				{Name: "multi", Command: multi},
+			}
+			adapter := New(shellType)
+			content := ""
+			for _, command := range commands {
+				content += adapter.FormatAlias(command.Name, command.Command)
+			}
+			entries, err := adapter.Parse(content)
+			if err != nil {
+				t.Fatalf("Parse: %v\n%s", err, content)
+			}
+			if len(entries) != len(commands) {
+				t.Fatalf("expected %d entries, got %+v\n%s", len(commands), entries, content)
+			}
+			for i, entry := range entries {
+				if entry.Name != commands[i].Name || entry.Command != commands[i].Command {
+					t.Errorf("parsed %s as %q, want %s as %q\n%s",
+						entry.Name, entry.Command, commands[i].Name, commands[i].Command, content)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePosix(t *testing.T) {
+	content := `# aliases
+alias ll='ls -l' la="ls -A"   # listings
+alias -g G='| grep'
+alias multi='echo one
+echo two'
+alias gs='git status' # don't forget
+alias gd='git diff'; alias gl="git log; git status" ; export EDITOR=vim
+export PATH="$HOME/bin:$PATH"
+
+mkcd() {
+    mkdir -p "$1" &&
+        cd "$1"
+}
+
+function greet {
+	echo "hello ${1:-world}"
+}
+
+up()
+{
+	cd ..
+}
+
+oneline() { ls; }
+`
+	want := []Entry{
+		{Name: "ll", Command: "ls -l", Kind: KindAlias, Line: 2},
+		{Name: "la", Command: "ls -A", Kind: KindAlias, Line: 2},
+		{Name: "G", Command: "| grep", Kind: KindAlias, Line: 3},
+		{Name: "multi", Command: "echo one\necho two", Kind: KindAlias, Line: 4},
+		{Name: "gs", Command: "git status", Kind: KindAlias, Line: 6},
+		{Name: "gd", Command: "git diff", Kind: KindAlias, Line: 7},
+		{Name: "gl", Command: "git log; git status", Kind: KindAlias, Line: 7},
+		{Name: "mkcd", Command: "mkdir -p \"$1\" &&\n    cd \"$1\"", Kind: KindFunction, Line: 10},
+		{Name: "greet", Command: `echo "hello ${1:-world}"`, Kind: KindFunction, Line: 15},
+		{Name: "up", Command: "cd ..", Kind: KindFunction, Line: 19},
+		{Name: "oneline", Command: "ls", Kind: KindFunction, Line: 24},
+	}
+	got, err := New("bash").Parse(content)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseFish(t *testing.T) {
+	content := `# fish aliases
+alias ll 'ls -l'
+alias la="ls -A"
+
+function mkcd --description 'make and enter'
+    # comment inside
+    mkdir -p $argv[1]
+    if test -d $argv[1]; cd $argv[1]; end
+    for f in *; echo $f; end
+end
+`
+	want := []Entry{
+		{Name: "ll", Command: "ls -l", Kind: KindAlias, Line: 2},
+		{Name: "la", Command: "ls -A", Kind: KindAlias, Line: 3},
+		{
+			Name:    "mkcd",
+			Command: "# comment inside\nmkdir -p $argv[1]\nif test -d $argv[1]; cd $argv[1]; end\nfor f in *; echo $f; end",
+			Kind:    KindFunction,
+			Line:    5,
+		},
+	}
+	got, err := New("fish").Parse(content)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if _, err := New("fish").Parse("function broken\n  if true\n  end\n"); err == nil {
+		t.Error("expected an error for a function without end")
+	}
+}
+
+func TestParseNushell(t *testing.T) {
+	content := `# nushell aliases
+alias ll = ls -l # long
+export alias "g s" = git status
+def --env mkcd [dir: string] {
+    mkdir $dir
+    cd $dir
+}
+export def greet [
+    name = "world"
+] { print $"hello ($name)" }
+`
+	want := []Entry{
+		{Name: "ll", Command: "ls -l", Kind: KindAlias, Line: 2},
+		{Name: "g s", Command: "git status", Kind: KindAlias, Line: 3},
+		{Name: "mkcd", Command: "mkdir $dir\ncd $dir", Kind: KindFunction, Line: 4},
+		{Name: "greet", Command: `print $"hello ($name)"`, Kind: KindFunction, Line: 8},
+	}
+	got, err := New("nu").Parse(content)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := New("bash").Parse("alias broken='never closed\n"); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+	if _, err := New("bash").Parse("f() {\n  ls\n"); err == nil {
+		t.Error("expected an error for a missing closing brace")
+	}
+	if _, err := New("elvish").Parse("fn x { }"); !errors.Is(err, ErrNoParser) {
+		t.Errorf("expected ErrNoParser, got %v", err)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.sh")
+	if err := os.WriteFile(path, []byte("alias gs='git status'\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	entries, err := New("sh").ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Location() != path+":1" {
+		t.Errorf("ParseFile() = %+v", entries)
+	}
+}
+
+func TestFindCollisions(t *testing.T) {
+	first := []Entry{{Name: "gs"}, {Name: "ll"}, {Name: "gs", Line: 9}}
+	second := []Entry{{Name: "ll"}, {Name: "up"}}
+	collisions := FindCollisions(first, second)
+	if len(collisions) != 2 || collisions[0].Name != "gs" || collisions[1].Name != "ll" {
+		t.Fatalf("FindCollisions() = %+v", collisions)
+	}
+	if len(collisions[0].Entries) != 2 || collisions[0].Entries[1].Line != 9 {
+		t.Errorf("expected both gs entries, got %+v", collisions[0].Entries)
+	}
+}
+
+func TestShellForPath(t *testing.T) {
+	tests := map[string]string{
+		"/home/me/.bashrc":                 "bash",
+		"/home/me/.zshrc":                  "zsh",
+		"~/.config/fish/functions/ll.fish": "fish",
+		"aliases.nu":                       "nu",
+		"profile.ps1":                      "pwsh",
+		"aliases.sh":                       "",
+		"notes.txt":                        "",
+	}
+	for path, want := range tests {
+		if got := ShellForPath(path); got != want {
+			t.Errorf("ShellForPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
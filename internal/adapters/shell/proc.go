@@ -0,0 +1,100 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// procRoot is where process information is read from. Systems without
+// /proc skip parent process detection.
+var procRoot = "/proc"
+
+// maxAncestors limits how far up the process tree ParentShell looks, so
+// a tool started from an editor or multiplexer still finds the shell.
+const maxAncestors = 4
+
+// ParentShell returns the type of the nearest interactive shell among this
+// process's ancestors, read from /proc. Shells running a script or a -c
+// command, such as the sh an editor or make starts, are passed over.
+// Returns "" when there is none or /proc is unavailable.
+func ParentShell() string {
+	return ancestorShell(os.Getppid())
+}
+
+// ancestorShell walks up the process tree from pid looking for a shell.
+func ancestorShell(pid int) string {
+	for range maxAncestors {
+		if pid <= 1 {
+			return ""
+		}
+		name, ppid, ok := readProcStat(pid)
+		if !ok {
+			return ""
+		}
+		if shell := normalizeShellName(name); shell != "" && interactive(pid) {
+			return shell
+		}
+		pid = ppid
+	}
+	return ""
+}
+
+// readProcStat reads the command name and parent pid of a process from
+// /proc/<pid>/stat, which starts "pid (comm) state ppid". The name may
+// itself contain spaces and parentheses, so it ends at the last ")".
+func readProcStat(pid int) (string, int, bool) {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return "", 0, false
+	}
+	stat := string(data)
+	open := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if open < 0 || end < open {
+		return "", 0, false
+	}
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 2 {
+		return "", 0, false
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return stat[open+1 : end], ppid, true
+}
+
+// interactive reports whether the shell process pid reads commands from
+// the terminal, judged by its arguments in /proc/<pid>/cmdline: a script
+// path or a command option means it does not. Processes whose arguments
+// cannot be read are assumed to be interactive.
+func interactive(pid int) bool {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return true
+	}
+	args := strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, "-") || commandOptions[strings.ToLower(arg)] {
+			return false
+		}
+		// Bundled short options such as -lc
+		if !strings.HasPrefix(arg, "--") && len(arg) <= 4 && strings.Contains(arg, "c") {
+			return false
+		}
+	}
+	return true
+}
+
+// commandOptions are the options that make a shell run a command or file
+// instead of reading from the terminal.
+var commandOptions = map[string]bool{
+	"--command":         true,
+	"--commands":        true,
+	"-command":          true,
+	"-file":             true,
+	"-noninteractive":   true,
+	"--non-interactive": true,
+}
@@ -0,0 +1,146 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuotePosix quotes s as a single word for sh, bash and zsh. Single quotes
+// keep everything literal, including $, backslashes and newlines, so the
+// only character needing care is the single quote itself.
+func QuotePosix(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// QuoteFish quotes s as a single word for fish. Inside fish single quotes
+// only \' and \\ are escapes, so backslashes must be doubled as well.
+func QuoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", `\'`)
+	return "'" + s + "'"
+}
+
+// QuoteNushell quotes s as a nushell string. Single-quoted strings are
+// raw but cannot contain a single quote, so those values use a
+// double-quoted string with escapes instead. Plain double-quoted strings
+// do not interpolate in nushell.
+func QuoteNushell(s string) string {
+	if !strings.ContainsAny(s, "'\r\n\t") {
+		return "'" + s + "'"
+	}
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&builder, `\u{%x}`, r)
+				continue
+			}
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+// QuotePowerShell quotes s as a verbatim PowerShell string. PowerShell
+// also treats the typographic single quotes as quotes, so every one of
+// them is doubled.
+func QuotePowerShell(s string) string {
+	var builder strings.Builder
+	builder.WriteByte('\'')
+	for _, r := range s {
+		if isPowerShellQuote(r) {
+			builder.WriteRune(r)
+		}
+		builder.WriteRune(r)
+	}
+	builder.WriteByte('\'')
+	return builder.String()
+}
+
+// isPowerShellQuote reports whether r ends a PowerShell single-quoted
+// string.
+func isPowerShellQuote(r rune) bool {
+	switch r {
+	case '\'', '\u2018', '\u2019', '\u201a', '\u201b':
+		return true
+	}
+	return false
+}
+
+// QuotePython quotes s as a Python string literal, which is how xonsh
+// takes alias and variable values.
+func QuotePython(s string) string {
+	var builder strings.Builder
+	builder.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'':
+			builder.WriteString(`\'`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&builder, `\x%02x`, r)
+				continue
+			}
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('\'')
+	return builder.String()
+}
+
+// QuoteElvish quotes s as an elvish single-quoted string, where a doubled
+// quote is the only escape.
+func QuoteElvish(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// QuoteCsh quotes s as a single word for tcsh and csh. History
+// substitution still applies inside single quotes, so ! is escaped, and a
+// newline must follow a backslash to stay inside the word.
+func QuoteCsh(s string) string {
+	s = strings.ReplaceAll(s, "'", `'\''`)
+	s = strings.ReplaceAll(s, "!", `\!`)
+	s = strings.ReplaceAll(s, "\n", "\\\n")
+	return "'" + s + "'"
+}
+
+// Quote quotes s as a single word for the adapter's shell.
+func (a *Adapter) Quote(s string) string {
+	switch a.shellType {
+	case "fish":
+		return QuoteFish(s)
+	case "nu":
+		return QuoteNushell(s)
+	case "pwsh":
+		return QuotePowerShell(s)
+	case "xonsh":
+		return QuotePython(s)
+	case "elvish":
+		return QuoteElvish(s)
+	case "tcsh", "csh":
+		return QuoteCsh(s)
+	default: // bash, zsh, sh, dash, ksh
+		return QuotePosix(s)
+	}
+}
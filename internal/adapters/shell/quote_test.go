@@ -0,0 +1,283 @@
+package shell
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/utils"
+)
+
+// quoteCorpus holds values that have broken naive quoting.
+var quoteCorpus = []string{
+	"",
+	"plain",
+	"git log --oneline",
+	"it's",
+	`say "hi"`,
+	"$HOME and ${USER} and $(whoami)",
+	"`backticks`",
+	`back\slash`,
+	`trailing\`,
+	`\'`,
+	"bang! and \\!",
+	"curly ‘quotes’",
+	"line one\nline two",
+	"tab\there\r\n",
+	"mixed 'single' and \"double\" quotes",
+	"héllo ✓",
+	"control\x01char",
+	"semi; colon | pipe & amp",
+}
+
+// The parsers below are reference implementations of each shell's rules
+// for a single quoted word, used to check that quoting round-trips.
+
+func parsePosixWord(s string) (string, error) {
+	words, err := utils.SplitWords(s)
+	if err != nil {
+		return "", err
+	}
+	if len(words) != 1 {
+		return "", errors.New("expected a single word")
+	}
+	return words[0], nil
+}
+
+func parseFishWord(s string) (string, error) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", errors.New("expected a single-quoted word")
+	}
+	var builder strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body) && (body[i+1] == '\\' || body[i+1] == '\''):
+			builder.WriteByte(body[i+1])
+			i++
+		case c == '\'':
+			return "", errors.New("unescaped quote inside the word")
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String(), nil
+}
+
+func parseNushellString(s string) (string, error) {
+	if len(s) < 2 {
+		return "", errors.New("expected a quoted string")
+	}
+	body := s[1 : len(s)-1]
+	switch {
+	case s[0] == '\'' && s[len(s)-1] == '\'':
+		if strings.Contains(body, "'") {
+			return "", errors.New("single-quoted strings cannot contain quotes")
+		}
+		return body, nil
+	case s[0] != '"' || s[len(s)-1] != '"':
+		return "", errors.New("expected a quoted string")
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '"' {
+			return "", errors.New("unescaped quote inside the string")
+		}
+		if c != '\\' {
+			builder.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(body) {
+			return "", errors.New("trailing backslash")
+		}
+		switch body[i] {
+		case '"', '\\', '\'', '/':
+			builder.WriteByte(body[i])
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'u':
+			end := strings.IndexByte(body[i:], '}')
+			if end < 0 || body[i+1] != '{' {
+				return "", errors.New("bad unicode escape")
+			}
+			code, err := strconv.ParseInt(body[i+2:i+end], 16, 32)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteRune(rune(code))
+			i += end
+		default:
+			return "", errors.New("unknown escape")
+		}
+	}
+	return builder.String(), nil
+}
+
+func parsePowerShellString(s string) (string, error) {
+	runes := []rune(s)
+	if len(runes) < 2 || runes[0] != '\'' || runes[len(runes)-1] != '\'' {
+		return "", errors.New("expected a single-quoted string")
+	}
+	var builder strings.Builder
+	body := runes[1 : len(runes)-1]
+	for i := 0; i < len(body); i++ {
+		if isPowerShellQuote(body[i]) {
+			if i+1 >= len(body) || !isPowerShellQuote(body[i+1]) {
+				return "", errors.New("unescaped quote inside the string")
+			}
+			i++
+		}
+		builder.WriteRune(body[i])
+	}
+	return builder.String(), nil
+}
+
+func parsePythonString(s string) (string, error) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", errors.New("expected a single-quoted string")
+	}
+	var builder strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\'' || c == '\n':
+			return "", errors.New("unescaped quote or newline inside the string")
+		case c != '\\':
+			builder.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(body) {
+			return "", errors.New("trailing backslash")
+		}
+		switch body[i] {
+		case '\'', '\\':
+			builder.WriteByte(body[i])
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'x':
+			if i+2 >= len(body) {
+				return "", errors.New("short hex escape")
+			}
+			code, err := strconv.ParseUint(body[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteByte(byte(code))
+			i += 2
+		default:
+			return "", errors.New("unknown escape")
+		}
+	}
+	return builder.String(), nil
+}
+
+func parseElvishString(s string) (string, error) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", errors.New("expected a single-quoted string")
+	}
+	body := s[1 : len(s)-1]
+	if strings.Count(body, "'")%2 != 0 || strings.Contains(strings.ReplaceAll(body, "''", ""), "'") {
+		return "", errors.New("unescaped quote inside the string")
+	}
+	return strings.ReplaceAll(body, "''", "'"), nil
+}
+
+// parseCshWord follows tcsh: outside quotes a backslash escapes the next
+// character, and inside single quotes only \! and a backslash before a
+// newline are escapes. A bare newline or ! in the word is an error.
+func parseCshWord(s string) (string, error) {
+	var builder strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '\\' && i+1 < len(s) && (!quoted || s[i+1] == '!' || s[i+1] == '\n'):
+			builder.WriteByte(s[i+1])
+			i++
+		case c == '\n':
+			return "", errors.New("unescaped newline")
+		case c == '!':
+			return "", errors.New("unescaped history substitution")
+		case !quoted && (c == ' ' || c == '\t'):
+			return "", errors.New("expected a single word")
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	if quoted {
+		return "", errors.New("unterminated quote")
+	}
+	return builder.String(), nil
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	shells := []struct {
+		name  string
+		quote func(string) string
+		parse func(string) (string, error)
+	}{
+		{"posix", QuotePosix, parsePosixWord},
+		{"fish", QuoteFish, parseFishWord},
+		{"nushell", QuoteNushell, parseNushellString},
+		{"powershell", QuotePowerShell, parsePowerShellString},
+		{"xonsh", QuotePython, parsePythonString},
+		{"elvish", QuoteElvish, parseElvishString},
+		{"tcsh", QuoteCsh, parseCshWord},
+	}
+	for _, shell := range shells {
+		t.Run(shell.name, func(t *testing.T) {
+			for _, value := range quoteCorpus {
+				quoted := shell.quote(value)
+				got, err := shell.parse(quoted)
+				if err != nil {
+					t.Errorf("%s does not parse: %v", quoted, err)
+					continue
+				}
+				if got != value {
+					t.Errorf("%q quoted as %s parses back as %q", value, quoted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAdapterQuote(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"bash", `'it'\''s'`},
+		{"zsh", `'it'\''s'`},
+		{"fish", `'it\'s'`},
+		{"nu", `"it's"`},
+		{"nushell", `"it's"`},
+		{"pwsh", `'it''s'`},
+		{"powershell", `'it''s'`},
+		{"xonsh", `'it\'s'`},
+		{"elvish", `'it''s'`},
+		{"csh", `'it'\''s'`},
+		{"dash", `'it'\''s'`},
+	}
+	for _, tt := range tests {
+		if got := New(tt.shell).Quote("it's"); got != tt.want {
+			t.Errorf("%s: Quote(it's) = %s, want %s", tt.shell, got, tt.want)
+		}
+	}
+}
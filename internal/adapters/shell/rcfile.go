@@ -0,0 +1,158 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-cli-template/internal/utils"
+)
+
+// BlockStart returns the line that opens the block program manages in an
+// rc file.
+func BlockStart(program string) string {
+	return fmt.Sprintf("# >>> %s >>>", program)
+}
+
+// BlockEnd returns the line that closes the block program manages in an
+// rc file.
+func BlockEnd(program string) string {
+	return fmt.Sprintf("# <<< %s <<<", program)
+}
+
+// FormatSource formats a statement that loads the script at path. Where
+// the shell allows it, a missing script is skipped rather than an error.
+func (a *Adapter) FormatSource(path string) string {
+	quoted := a.Quote(path)
+	switch a.shellType {
+	case "fish":
+		return fmt.Sprintf("test -f %s; and source %s", quoted, quoted)
+	case "nu", "xonsh":
+		// nushell resolves sources before running, so it cannot be guarded
+		return "source " + quoted
+	case "pwsh":
+		return fmt.Sprintf("if (Test-Path %s) { . %s }", quoted, quoted)
+	case "elvish":
+		return fmt.Sprintf("eval (slurp < %s)", quoted)
+	case "tcsh", "csh":
+		return fmt.Sprintf("if ( -f %s ) source %s", quoted, quoted)
+	default: // bash, zsh, sh, dash, ksh
+		return fmt.Sprintf("[ -f %s ] && . %s", quoted, quoted)
+	}
+}
+
+// FormatBlock formats the block program adds to an rc file to load the
+// script at path.
+func (a *Adapter) FormatBlock(program, path string) string {
+	return strings.Join([]string{
+		BlockStart(program),
+		fmt.Sprintf("# Managed by %s. Remove with: %s shell uninstall", program, program),
+		a.FormatSource(path),
+		BlockEnd(program),
+	}, "\n") + "\n"
+}
+
+// InstallBlock returns content with program's block loading path, either
+// replacing the block already there or appended after a blank line.
+// Installing the same block again leaves content unchanged. A start marker
+// without an end marker is an error, since the block's extent is unknown.
+func (a *Adapter) InstallBlock(content, program, path string) (string, error) {
+	block := a.FormatBlock(program, path)
+	start, end, ok, err := findBlock(content, program)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return content[:start] + block + content[end:], nil
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return content + block, nil
+}
+
+// RemoveBlock returns content without program's block, along with the
+// blank line InstallBlock put before it, and whether a block was found.
+// Like InstallBlock, it fails on a start marker without an end marker.
+func RemoveBlock(content, program string) (string, bool, error) {
+	start, end, ok, err := findBlock(content, program)
+	if err != nil || !ok {
+		return content, false, err
+	}
+	before, after := content[:start], content[end:]
+	if strings.HasSuffix(before, "\n\n") {
+		before = before[:len(before)-1]
+	}
+	return before + after, true, nil
+}
+
+// findBlock returns the byte range of program's block in content, from
+// the start of its opening line to the end of its closing line. A start
+// marker that is never closed is an error.
+func findBlock(content, program string) (int, int, bool, error) {
+	startLine, endLine := BlockStart(program), BlockEnd(program)
+	offset := 0
+	start := -1
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case start < 0 && trimmed == startLine:
+			start = offset
+		case start >= 0 && trimmed == endLine:
+			return start, offset + len(line), true, nil
+		}
+		offset += len(line)
+	}
+	if start >= 0 {
+		return 0, 0, false, fmt.Errorf("found %q without a matching %q; fix or remove the block by hand", startLine, endLine)
+	}
+	return 0, 0, false, nil
+}
+
+// RCFile returns the startup file the shell reads for interactive
+// sessions, such as ~/.bashrc or fish's config.fish.
+func (a *Adapter) RCFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	switch a.shellType {
+	case "bash":
+		return filepath.Join(home, ".bashrc"), nil
+	case "zsh":
+		if dir := os.Getenv("ZDOTDIR"); dir != "" {
+			return filepath.Join(dir, ".zshrc"), nil
+		}
+		return filepath.Join(home, ".zshrc"), nil
+	case "ksh":
+		return filepath.Join(home, ".kshrc"), nil
+	case "fish":
+		return filepath.Join(utils.XDGConfigHome(), "fish", "config.fish"), nil
+	case "nu":
+		// nushell only follows XDG on macOS when XDG_CONFIG_HOME is set
+		if runtime.GOOS == "darwin" && os.Getenv("XDG_CONFIG_HOME") == "" {
+			return filepath.Join(home, "Library", "Application Support", "nushell", "config.nu"), nil
+		}
+		return filepath.Join(utils.XDGConfigHome(), "nushell", "config.nu"), nil
+	case "pwsh":
+		if runtime.GOOS == "windows" {
+			return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), nil
+		}
+		return filepath.Join(utils.XDGConfigHome(), "powershell", "Microsoft.PowerShell_profile.ps1"), nil
+	case "xonsh":
+		return filepath.Join(home, ".xonshrc"), nil
+	case "elvish":
+		return filepath.Join(utils.XDGConfigHome(), "elvish", "rc.elv"), nil
+	case "tcsh":
+		return filepath.Join(home, ".tcshrc"), nil
+	case "csh":
+		return filepath.Join(home, ".cshrc"), nil
+	default: // sh, dash
+		return filepath.Join(home, ".profile"), nil
+	}
+}
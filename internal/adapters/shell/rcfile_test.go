@@ -0,0 +1,103 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInstallBlock(t *testing.T) {
+	adapter := New("bash")
+	block := adapter.FormatBlock("tool", "/data/tool/bash.sh")
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty file", "", block},
+		{"appends after a blank line", "export A=1\n", "export A=1\n\n" + block},
+		{"adds a missing newline", "export A=1", "export A=1\n\n" + block},
+		{
+			name:    "replaces in place",
+			content: "a\n# >>> tool >>>\nold\n# <<< tool <<<\nb\n",
+			want:    "a\n" + block + "b\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.InstallBlock(tt.content, "tool", "/data/tool/bash.sh")
+			if err != nil {
+				t.Fatalf("InstallBlock: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("InstallBlock() = %q, want %q", got, tt.want)
+			}
+			if again, _ := adapter.InstallBlock(got, "tool", "/data/tool/bash.sh"); again != got {
+				t.Errorf("installing twice changed the file:\n%s", again)
+			}
+		})
+	}
+
+	if _, err := adapter.InstallBlock("a\n# >>> tool >>>\nno end\n", "tool", "/data/tool/bash.sh"); err == nil {
+		t.Error("expected an error for a start marker without an end marker")
+	}
+}
+
+func TestRemoveBlock(t *testing.T) {
+	adapter := New("fish")
+	for _, original := range []string{"", "set -x A 1\n", "a\n\nb\n"} {
+		installed, err := adapter.InstallBlock(original, "tool", "/data/tool/fish.fish")
+		if err != nil {
+			t.Fatalf("InstallBlock: %v", err)
+		}
+		got, found, err := RemoveBlock(installed, "tool")
+		if err != nil || !found || got != original {
+			t.Errorf("RemoveBlock(InstallBlock(%q)) = %q, %v, %v", original, got, found, err)
+		}
+	}
+	if _, _, err := RemoveBlock("# >>> tool >>>\nno end\n", "tool"); err == nil {
+		t.Error("expected an error for a start marker without an end marker")
+	}
+	if _, found, err := RemoveBlock("# >>> other >>>\n# <<< other <<<\n", "tool"); found || err != nil {
+		t.Errorf("expected another program's block to be left alone, got %v, %v", found, err)
+	}
+}
+
+func TestFormatSource(t *testing.T) {
+	tests := map[string]string{
+		"zsh":    `[ -f '/it'\''s/x.sh' ] && . '/it'\''s/x.sh'`,
+		"fish":   `test -f '/it\'s/x.sh'; and source '/it\'s/x.sh'`,
+		"nu":     `source "/it's/x.sh"`,
+		"pwsh":   `if (Test-Path '/it''s/x.sh') { . '/it''s/x.sh' }`,
+		"elvish": `eval (slurp < '/it''s/x.sh')`,
+		"tcsh":   `if ( -f '/it'\''s/x.sh' ) source '/it'\''s/x.sh'`,
+	}
+	for shellType, want := range tests {
+		if got := New(shellType).FormatSource("/it's/x.sh"); got != want {
+			t.Errorf("%s: FormatSource() = %s, want %s", shellType, got, want)
+		}
+	}
+}
+
+func TestRCFile(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "/home/me/.config")
+	tests := map[string]string{
+		"bash":   "/home/me/.bashrc",
+		"zsh":    "/home/me/.zshrc",
+		"fish":   "/home/me/.config/fish/config.fish",
+		"elvish": "/home/me/.config/elvish/rc.elv",
+		"dash":   "/home/me/.profile",
+	}
+	for shellType, want := range tests {
+		got, err := New(shellType).RCFile()
+		if err != nil || got != want {
+			t.Errorf("%s: RCFile() = %s, %v, want %s", shellType, got, err, want)
+		}
+	}
+
+	t.Setenv("ZDOTDIR", "/home/me/.config/zsh")
+	if got, _ := New("zsh").RCFile(); !strings.HasPrefix(got, "/home/me/.config/zsh/") {
+		t.Errorf("expected ZDOTDIR to hold .zshrc, got %s", got)
+	}
+}
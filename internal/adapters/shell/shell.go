@@ -1,10 +1,10 @@
 package shell
 
 import (
-"fmt"
-"os"
-"path/filepath"
-"strings"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Adapter handles shell-specific syntax for aliases and functions.
@@ -12,97 +12,233 @@ type Adapter struct {
 	shellType string
 }
 
-// New creates a new shell adapter for the given shell type.
+// New creates a new shell adapter for the given shell type. Names are
+// normalized, so "powershell" and "pwsh" give the same adapter. Unknown
+// shells are treated as POSIX-compatible.
 func New(shellType string) *Adapter {
+	if normalized := normalizeShellName(shellType); normalized != "" {
+		shellType = normalized
+	}
 	return &Adapter{shellType: shellType}
 }
 
-// FormatAlias formats a bookmark alias for the configured shell.
+// Shell returns the normalized shell type of the adapter.
+func (a *Adapter) Shell() string {
+	return a.shellType
+}
+
+// FormatAlias formats a bookmark alias for the configured shell. Arguments
+// given to the alias are passed on to the command where the shell allows
+// it. Commands that span several lines, or that a nushell alias cannot
+// hold, are written as functions instead.
 func (a *Adapter) FormatAlias(alias, command string) string {
 	switch a.shellType {
 	case "fish":
-		return a.formatFishFunction(alias, command)
-	case "nu", "nushell":
+		return a.formatFishAlias(alias, command)
+	case "nu":
 		return a.formatNushellAlias(alias, command)
-	default: // bash, zsh, sh
+	case "pwsh":
+		return a.FormatFunction(alias, strings.TrimRight(command, "\n")+" @args")
+	case "xonsh":
+		// String aliases get the arguments appended, and xonsh runs ones
+		// with newlines or operators as a script
+		return fmt.Sprintf("aliases[%s] = %s\n\n", QuotePython(alias), QuotePython(command))
+	case "elvish":
+		return fmt.Sprintf("fn %s {|@args|\n%s $@args\n}\n\n", alias, indentLines(command))
+	case "tcsh", "csh":
+		return a.formatCshAlias(alias, command)
+	default: // bash, zsh, sh, dash, ksh
 		return a.formatPosixAlias(alias, command)
 	}
 }
 
+// FormatFunction formats a function whose body is one or more lines of
+// shell code, indented as written. csh has no functions, so its lines are
+// joined into an alias.
+func (a *Adapter) FormatFunction(name, body string) string {
+	lines := indentLines(body)
+	switch a.shellType {
+	case "fish":
+		return fmt.Sprintf("function %s\n%s\nend\n\n", name, lines)
+	case "nu":
+		return fmt.Sprintf("def %s [] {\n%s\n}\n\n", name, lines)
+	case "pwsh":
+		return fmt.Sprintf("function %s {\n%s\n}\n\n", name, lines)
+	case "xonsh":
+		return fmt.Sprintf("aliases[%s] = %s\n\n", QuotePython(name), QuotePython(strings.TrimRight(body, "\n")))
+	case "elvish":
+		return fmt.Sprintf("fn %s {\n%s\n}\n\n", name, lines)
+	case "tcsh", "csh":
+		return fmt.Sprintf("alias %s %s\n\n", name, QuoteCsh(joinLines(body)))
+	default: // bash, zsh, sh, dash, ksh
+		return fmt.Sprintf("%s() {\n%s\n}\n\n", name, lines)
+	}
+}
+
+// FormatEnv formats a statement exporting an environment variable. The
+// value is quoted so it is set exactly as given.
+func (a *Adapter) FormatEnv(name, value string) string {
+	switch a.shellType {
+	case "fish":
+		return fmt.Sprintf("set -gx %s %s\n", name, QuoteFish(value))
+	case "nu":
+		return fmt.Sprintf("$env.%s = %s\n", name, QuoteNushell(value))
+	case "pwsh":
+		return fmt.Sprintf("$env:%s = %s\n", name, QuotePowerShell(value))
+	case "xonsh":
+		return fmt.Sprintf("$%s = %s\n", name, QuotePython(value))
+	case "elvish":
+		return fmt.Sprintf("set-env %s %s\n", name, QuoteElvish(value))
+	case "tcsh", "csh":
+		return fmt.Sprintf("setenv %s %s\n", name, QuoteCsh(value))
+	default: // bash, zsh, sh, dash, ksh
+		return fmt.Sprintf("export %s=%s\n", name, QuotePosix(value))
+	}
+}
+
 // formatPosixAlias formats an alias for POSIX-compatible shells (bash, zsh, sh).
 func (a *Adapter) formatPosixAlias(alias, command string) string {
-	escaped := strings.ReplaceAll(command, "'", "'\\''")
-	return fmt.Sprintf("alias %s='%s'\n\n", alias, escaped)
+	return fmt.Sprintf("alias %s=%s\n\n", alias, QuotePosix(command))
 }
 
-// formatFishFunction formats a function for fish shell.
-func (a *Adapter) formatFishFunction(alias, command string) string {
-	return fmt.Sprintf("function %s\n\t%s\nend\n\n", alias, command)
+// formatFishAlias formats an alias as a fish function, which is what
+// fish's own alias command creates.
+func (a *Adapter) formatFishAlias(alias, command string) string {
+	description := QuoteFish(fmt.Sprintf("alias %s=%s", alias, joinLines(command)))
+	if strings.Contains(command, "\n") {
+		return fmt.Sprintf("function %s --description %s\n%s\nend\n\n", alias, description, indentLines(command))
+	}
+	return fmt.Sprintf("function %s --wraps %s --description %s\n\t%s $argv\nend\n\n",
+		alias, QuoteFish(command), description, command)
 }
 
-// formatNushellAlias formats an alias for nushell.
+// formatNushellAlias formats an alias for nushell. Nushell aliases hold a
+// single command, so pipelines, command lists and multi-line commands
+// become a def instead.
 func (a *Adapter) formatNushellAlias(alias, command string) string {
+	if strings.ContainsAny(command, "|;\n") {
+		return a.FormatFunction(alias, command)
+	}
 	// Nushell requires spaces around = in alias
 	return fmt.Sprintf("alias %s = %s\n\n", alias, command)
 }
 
+// formatCshAlias formats an alias for tcsh and csh. Aliases cannot span
+// lines, so multi-line commands are joined with semicolons, and \!* passes
+// the alias arguments on.
+func (a *Adapter) formatCshAlias(alias, command string) string {
+	return fmt.Sprintf("alias %s %s\n\n", alias, QuoteCsh(joinLines(command)+" !*"))
+}
+
+// indentLines indents each line of body by one tab.
+func indentLines(body string) string {
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "\t" + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// joinLines joins the non-blank lines of body into one command list.
+func joinLines(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "; ")
+}
+
 // GetFileExtension returns the appropriate file extension for the shell.
 func GetFileExtension(shellType string) string {
-	switch shellType {
+	switch normalizeShellName(shellType) {
 	case "fish":
 		return ".fish"
-	case "nu", "nushell":
+	case "nu":
 		return ".nu"
-	default: // bash, zsh, sh
+	case "pwsh":
+		return ".ps1"
+	case "xonsh":
+		return ".xsh"
+	case "elvish":
+		return ".elv"
+	case "tcsh", "csh":
+		return ".csh"
+	default: // bash, zsh, sh, dash, ksh
 		return ".sh"
 	}
 }
 
-// DetectShell attempts to detect the current shell from environment variables.
-// Returns the detected shell type or "bash" as a fallback.
+// DetectShell attempts to detect the current shell from $SHELL. $SHELL
+// names the login shell, so an interactive shell among this process's
+// ancestors wins when it differs, as when fish is run from zsh. Returns
+// "bash" as a fallback.
 func DetectShell() string {
-	// Check SHELL environment variable (most common)
+	login := ""
 	if shellPath := os.Getenv("SHELL"); shellPath != "" {
-		shellName := filepath.Base(shellPath)
-		return normalizeShellName(shellName)
+		login = normalizeShellName(filepath.Base(shellPath))
 	}
-
-	// Check for shell-specific environment variables
-	if os.Getenv("BASH_VERSION") != "" {
-		return "bash"
+	if parent := ParentShell(); parent != "" && parent != login {
+		return parent
 	}
-	if os.Getenv("ZSH_VERSION") != "" {
-		return "zsh"
+	if login != "" {
+		return login
 	}
-	if os.Getenv("FISH_VERSION") != "" {
-		return "fish"
+
+	// Check for shell-specific environment variables
+	versions := []struct{ env, shell string }{
+		{"BASH_VERSION", "bash"},
+		{"ZSH_VERSION", "zsh"},
+		{"FISH_VERSION", "fish"},
+		{"NU_VERSION", "nu"},
+		{"XONSH_VERSION", "xonsh"},
+		{"KSH_VERSION", "ksh"},
 	}
-	if os.Getenv("NU_VERSION") != "" {
-		return "nu"
+	for _, version := range versions {
+		if os.Getenv(version.env) != "" {
+			return version.shell
+		}
 	}
 
 	// Fallback to bash
 	return "bash"
 }
 
-// normalizeShellName normalizes shell names to standard identifiers.
+// shellNames maps executable names to the shell types the adapter knows.
+var shellNames = map[string]string{
+	"bash":       "bash",
+	"zsh":        "zsh",
+	"sh":         "sh",
+	"dash":       "dash",
+	"ksh":        "ksh",
+	"ksh93":      "ksh",
+	"mksh":       "ksh",
+	"oksh":       "ksh",
+	"pdksh":      "ksh",
+	"fish":       "fish",
+	"nu":         "nu",
+	"nushell":    "nu",
+	"pwsh":       "pwsh",
+	"powershell": "pwsh",
+	"xonsh":      "xonsh",
+	"elvish":     "elvish",
+	"tcsh":       "tcsh",
+	"csh":        "csh",
+}
+
+// Shells returns the shell types the adapter knows, sorted.
+func Shells() []string {
+	return []string{"bash", "csh", "dash", "elvish", "fish", "ksh", "nu", "pwsh", "sh", "tcsh", "xonsh", "zsh"}
+}
+
+// normalizeShellName maps an executable name such as "-zsh" or
+// "pwsh.exe" to a shell type. Names must match exactly, so "gnuplot" or
+// "menu" are not taken for nushell. Returns "" for unknown names.
 func normalizeShellName(name string) string {
-	name = strings.ToLower(name)
-	
-	// Handle common variations
-	switch {
-	case strings.Contains(name, "bash"):
-		return "bash"
-	case strings.Contains(name, "zsh"):
-		return "zsh"
-	case strings.Contains(name, "fish"):
-		return "fish"
-	case strings.Contains(name, "nu"):
-		return "nu"
-	case name == "sh":
-		return "sh"
-	default:
-		// If unknown, assume POSIX-compatible
-		return "bash"
-	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	// Login shells are started with a leading dash
+	name = strings.TrimPrefix(name, "-")
+	name = strings.TrimSuffix(name, ".exe")
+	return shellNames[name]
 }
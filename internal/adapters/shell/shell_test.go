@@ -0,0 +1,271 @@
+package shell
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFormatAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		shell   string
+		alias   string
+		command string
+		want    string
+	}{
+		{
+			name:    "posix quotes",
+			shell:   "bash",
+			alias:   "gs",
+			command: `echo "it's $HOME"`,
+			want:    "alias gs='echo \"it'\\''s $HOME\"'\n\n",
+		},
+		{
+			name:    "fish wraps the command",
+			shell:   "fish",
+			alias:   "gs",
+			command: "git status",
+			want: "function gs --wraps 'git status' --description 'alias gs=git status'\n" +
+				"\tgit status $argv\nend\n\n",
+		},
+		{
+			name:    "fish escapes quotes in the description",
+			shell:   "fish",
+			alias:   "hi",
+			command: `echo 'hi'`,
+			want: "function hi --wraps 'echo \\'hi\\'' --description 'alias hi=echo \\'hi\\''\n" +
+				"\techo 'hi' $argv\nend\n\n",
+		},
+		{
+			name:    "fish multi-line",
+			shell:   "fish",
+			alias:   "up",
+			command: "cd ..\nls",
+			want:    "function up --description 'alias up=cd ..; ls'\n\tcd ..\n\tls\nend\n\n",
+		},
+		{
+			name:    "nushell alias",
+			shell:   "nu",
+			alias:   "ll",
+			command: "ls -l",
+			want:    "alias ll = ls -l\n\n",
+		},
+		{
+			name:    "powershell function",
+			shell:   "pwsh",
+			alias:   "gs",
+			command: "git status",
+			want:    "function gs {\n\tgit status @args\n}\n\n",
+		},
+		{
+			name:    "xonsh string alias",
+			shell:   "xonsh",
+			alias:   "gs",
+			command: "git status && echo 'done'",
+			want:    "aliases['gs'] = 'git status && echo \\'done\\''\n\n",
+		},
+		{
+			name:    "elvish function",
+			shell:   "elvish",
+			alias:   "gs",
+			command: "git status",
+			want:    "fn gs {|@args|\n\tgit status $@args\n}\n\n",
+		},
+		{
+			name:    "tcsh passes arguments",
+			shell:   "tcsh",
+			alias:   "up",
+			command: "cd ..\nls",
+			want:    "alias up 'cd ..; ls \\!*'\n\n",
+		},
+		{
+			name:    "ksh is posix",
+			shell:   "ksh",
+			alias:   "gs",
+			command: "git status",
+			want:    "alias gs='git status'\n\n",
+		},
+		{
+			name:    "nushell pipeline becomes a def",
+			shell:   "nushell",
+			alias:   "big",
+			command: "ls | where size > 1mb",
+			want:    "def big [] {\n\tls | where size > 1mb\n}\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.shell).FormatAlias(tt.alias, tt.command); got != tt.want {
+				t.Errorf("FormatAlias() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFunction(t *testing.T) {
+	body := "cd \"$1\"\nls\n"
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"bash", "cl() {\n\tcd \"$1\"\n\tls\n}\n\n"},
+		{"fish", "function cl\n\tcd \"$1\"\n\tls\nend\n\n"},
+		{"nu", "def cl [] {\n\tcd \"$1\"\n\tls\n}\n\n"},
+		{"pwsh", "function cl {\n\tcd \"$1\"\n\tls\n}\n\n"},
+		{"elvish", "fn cl {\n\tcd \"$1\"\n\tls\n}\n\n"},
+		{"xonsh", "aliases['cl'] = 'cd \"$1\"\\nls'\n\n"},
+		{"csh", "alias cl 'cd \"$1\"; ls'\n\n"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.shell).FormatFunction("cl", body); got != tt.want {
+			t.Errorf("%s: FormatFunction() = %q, want %q", tt.shell, got, tt.want)
+		}
+	}
+}
+
+func TestFormatEnv(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"zsh", "export GREETING='it'\\''s $HOME'\n"},
+		{"fish", "set -gx GREETING 'it\\'s $HOME'\n"},
+		{"nu", "$env.GREETING = \"it's $HOME\"\n"},
+		{"pwsh", "$env:GREETING = 'it''s $HOME'\n"},
+		{"xonsh", "$GREETING = 'it\\'s $HOME'\n"},
+		{"elvish", "set-env GREETING 'it''s $HOME'\n"},
+		{"tcsh", "setenv GREETING 'it'\\''s $HOME'\n"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.shell).FormatEnv("GREETING", "it's $HOME"); got != tt.want {
+			t.Errorf("%s: FormatEnv() = %q, want %q", tt.shell, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeShellName(t *testing.T) {
+	tests := map[string]string{
+		"bash":           "bash",
+		"-zsh":           "zsh",
+		"nushell":        "nu",
+		"powershell.exe": "pwsh",
+		"PWSH":           "pwsh",
+		"mksh":           "ksh",
+		"tcsh":           "tcsh",
+		"dash":           "dash",
+		"xonsh":          "xonsh",
+		"elvish":         "elvish",
+		"menu":           "",
+		"gnuplot":        "",
+		"bash-wrapper":   "",
+	}
+	for name, want := range tests {
+		if got := normalizeShellName(name); got != want {
+			t.Errorf("normalizeShellName(%q) = %q, want %q", name, got, want)
+		}
+	}
+	for _, shell := range Shells() {
+		if got := normalizeShellName(shell); got != shell {
+			t.Errorf("Shells() lists %q, which normalizes to %q", shell, got)
+		}
+	}
+}
+
+func TestGetFileExtension(t *testing.T) {
+	tests := map[string]string{
+		"bash":       ".sh",
+		"dash":       ".sh",
+		"fish":       ".fish",
+		"nushell":    ".nu",
+		"powershell": ".ps1",
+		"xonsh":      ".xsh",
+		"elvish":     ".elv",
+		"csh":        ".csh",
+	}
+	for shell, want := range tests {
+		if got := GetFileExtension(shell); got != want {
+			t.Errorf("GetFileExtension(%q) = %q, want %q", shell, got, want)
+		}
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	old := procRoot
+	procRoot = t.TempDir()
+	t.Cleanup(func() { procRoot = old })
+
+	t.Setenv("SHELL", "/usr/local/bin/fish")
+	if got := DetectShell(); got != "fish" {
+		t.Errorf("DetectShell() = %q, want fish from $SHELL", got)
+	}
+}
+
+func TestAncestorShell(t *testing.T) {
+	root := t.TempDir()
+	old := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = old })
+
+	processes := map[int]struct{ stat, cmdline string }{
+		100: {"100 (go-cli-template) S 101 100", "go-cli-template\x00shell\x00install\x00"},
+		101: {"101 (vim (1)) S 102 101", "vim\x00"},
+		102: {"102 (fish) S 103 102", "fish\x00--login\x00"},
+		103: {"103 (zsh) S 1 103", "-zsh\x00"},
+		200: {"200 (go-cli-template) S 201 200", "go-cli-template\x00"},
+		201: {"201 (tmux: server) S 1 201", "tmux\x00"},
+		300: {"300 (go-cli-template) S 301 300", "go-cli-template\x00"},
+		301: {"301 (sh) S 302 301", "/bin/sh\x00-c\x00go-cli-template\x00"},
+		302: {"302 (bash) S 303 302", "bash\x00./build.sh\x00"},
+		303: {"303 (zsh) S 1 303", "zsh\x00-i\x00"},
+	}
+	for pid, process := range processes {
+		dir := filepath.Join(root, strconv.Itoa(pid))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(process.stat), 0o644); err != nil {
+			t.Fatalf("write stat: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cmdline"), []byte(process.cmdline), 0o644); err != nil {
+			t.Fatalf("write cmdline: %v", err)
+		}
+	}
+
+	if got := ancestorShell(100); got != "fish" {
+		t.Errorf("ancestorShell(100) = %q, want fish", got)
+	}
+	if got := ancestorShell(200); got != "" {
+		t.Errorf("ancestorShell(200) = %q, want no shell", got)
+	}
+	if got := ancestorShell(300); got != "zsh" {
+		t.Errorf("ancestorShell(300) = %q, want zsh past sh -c and a bash script", got)
+	}
+	if got := ancestorShell(999); got != "" {
+		t.Errorf("ancestorShell(999) = %q, want no shell for a missing process", got)
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"nu", "xonsh", "elvish", "tcsh"} {
+		script, err := New(shell).CompletionScript("my-tool")
+		if err != nil {
+			t.Errorf("%s: CompletionScript: %v", shell, err)
+			continue
+		}
+		if !strings.Contains(script, "my-tool __complete") && !strings.Contains(script, `"my-tool", "__complete"`) {
+			t.Errorf("%s: expected the script to call my-tool __complete:\n%s", shell, script)
+		}
+		if strings.Contains(script, "{{") {
+			t.Errorf("%s: unreplaced placeholder in:\n%s", shell, script)
+		}
+	}
+	for _, shell := range []string{"dash", "sh", "ksh", "bash"} {
+		if _, err := New(shell).CompletionScript("my-tool"); !errors.Is(err, ErrNoCompletion) {
+			t.Errorf("%s: expected ErrNoCompletion, got %v", shell, err)
+		}
+	}
+}
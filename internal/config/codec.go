@@ -0,0 +1,192 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Codec decodes and encodes one config file format.
+type Codec interface {
+	// Unmarshal decodes data into v, which is a struct pointer or a map.
+	Unmarshal(data []byte, v any) error
+	// Marshal encodes v in the codec's format.
+	Marshal(v any) ([]byte, error)
+}
+
+// codecs maps file extensions to codecs. Extensions are tried in
+// codecOrder when looking for a config file, so TOML wins over YAML and JSON.
+var (
+	codecs = map[string]Codec{
+		".toml": tomlCodec{},
+		".yaml": yamlCodec{},
+		".yml":  yamlCodec{},
+		".json": jsonCodec{},
+	}
+	codecOrder = []string{".toml", ".yaml", ".yml", ".json"}
+)
+
+// RegisterCodec adds or replaces the codec used for a file extension.
+func RegisterCodec(ext string, codec Codec) {
+	ext = normalizeExt(ext)
+	if _, ok := codecs[ext]; !ok {
+		codecOrder = append(codecOrder, ext)
+	}
+	codecs[ext] = codec
+}
+
+// Extensions returns the registered config file extensions in lookup order.
+func Extensions() []string {
+	return append([]string(nil), codecOrder...)
+}
+
+// CodecFor returns the codec registered for the extension of path. Files
+// with an unregistered extension are read as TOML.
+func CodecFor(path string) Codec {
+	if codec, ok := codecs[normalizeExt(filepath.Ext(path))]; ok {
+		return codec
+	}
+	return codecs[".toml"]
+}
+
+// FormatExt maps a format name such as "yaml" to its file extension.
+func FormatExt(format string) (string, error) {
+	ext := normalizeExt(format)
+	if _, ok := codecs[ext]; !ok {
+		return "", fmt.Errorf("unsupported config format %q (supported: %s)", format, strings.Join(codecOrder, ", "))
+	}
+	return ext, nil
+}
+
+// FindConfigFile returns the existing config file for path, trying each
+// registered extension in place of the one in path. When none exists, path
+// is returned unchanged with exists set to false.
+func FindConfigFile(path string) (string, bool, error) {
+	stem := strings.TrimSuffix(path, filepath.Ext(path))
+	for _, ext := range codecOrder {
+		candidate := stem + ext
+		exists, err := fileExists(candidate)
+		if err != nil {
+			return "", false, err
+		}
+		if exists {
+			return candidate, true, nil
+		}
+	}
+	return path, false, nil
+}
+
+// Convert re-encodes a config file's data from the format of path from
+// into the format of path to. Keys the config does not know are kept.
+func Convert(data []byte, from, to string) ([]byte, error) {
+	var raw map[string]any
+	if err := CodecFor(from).Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return CodecFor(to).Marshal(wholeNumbers(raw))
+}
+
+// wholeNumbers turns whole float64 values back into int64. JSON decodes
+// every number as a float, which TOML would then write as 1.0 and which
+// no longer decodes into an int field.
+func wholeNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = wholeNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = wholeNumbers(item)
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+			return int64(v)
+		}
+	}
+	return value
+}
+
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+type tomlCodec struct{}
+
+func (tomlCodec) Unmarshal(data []byte, v any) error {
+	return toml.Unmarshal(data, v)
+}
+
+func (tomlCodec) Marshal(v any) ([]byte, error) {
+	return toml.Marshal(v)
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Unmarshal(data []byte, v any) error {
+	return yaml.Unmarshal(data, v)
+}
+
+func (yamlCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// unknownKeys returns dotted keys in raw that partialConfig does not define.
+// Profile tables are checked against the same key set.
+func unknownKeys(raw map[string]any) []string {
+	known := knownKeys()
+	var unknown []string
+	for key, value := range raw {
+		if key == "profiles" {
+			profiles, _ := value.(map[string]any)
+			for name, profile := range profiles {
+				fields, _ := profile.(map[string]any)
+				for field := range fields {
+					if !known[field] || field == "profiles" {
+						unknown = append(unknown, fmt.Sprintf("profiles.%s.%s", name, field))
+					}
+				}
+			}
+			continue
+		}
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
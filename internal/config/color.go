@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+// colorKeys lists the config keys that hold colors.
+var colorKeys = []string{
+	"headings", "primary", "secondary", "text", "text_highlight",
+	"description_highlight", "tags", "flags", "muted", "accent", "border",
+	"success", "warning", "error", "info", "selection_background", "filter_match",
+}
+
+// normalizeColors rewrites { light = "..", dark = ".." } color tables in
+// raw, including those in profiles, into the "light|dark" form stored in
+// Config. Tables that cannot be converted are removed and reported. It
+// returns whether raw was changed.
+func normalizeColors(raw map[string]any) (bool, []string) {
+	changed, problems := normalizeColorTables(raw, "")
+	profiles, _ := raw["profiles"].(map[string]any)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile, ok := profiles[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		profileChanged, profileProblems := normalizeColorTables(profile, "profiles."+name+".")
+		changed = changed || profileChanged
+		problems = append(problems, profileProblems...)
+	}
+	return changed, problems
+}
+
+func normalizeColorTables(values map[string]any, prefix string) (bool, []string) {
+	changed := false
+	var problems []string
+	for _, key := range colorKeys {
+		table, ok := values[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		changed = true
+		color, err := adaptiveColorOf(table)
+		if err != nil {
+			delete(values, key)
+			problems = append(problems, fmt.Sprintf("%s%s: %v", prefix, key, err))
+			continue
+		}
+		values[key] = color
+	}
+	return changed, problems
+}
+
+// adaptiveColorOf converts a color table with light and dark keys.
+func adaptiveColorOf(table map[string]any) (string, error) {
+	var light, dark string
+	for key, value := range table {
+		color, ok := value.(string)
+		if !ok {
+			// Numeric colors may be written unquoted
+			switch v := value.(type) {
+			case int, int64, uint64, float64:
+				color = fmt.Sprint(v)
+			default:
+				return "", fmt.Errorf("%s must be a color string", key)
+			}
+		}
+		switch key {
+		case "light":
+			light = color
+		case "dark":
+			dark = color
+		default:
+			return "", fmt.Errorf("unknown key %q (expected light and dark)", key)
+		}
+	}
+	if light == "" && dark == "" {
+		return "", fmt.Errorf("set light and dark colors")
+	}
+	return domain.AdaptiveColor(light, dark), nil
+}
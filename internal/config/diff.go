@@ -0,0 +1,137 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+// KeyDiff compares a single config key across layers. Global and Local are
+// nil when the layer does not set the key.
+type KeyDiff struct {
+	Key       string `json:"key"`
+	Default   any    `json:"default"`
+	Global    any    `json:"global"`
+	Local     any    `json:"local"`
+	Effective any    `json:"effective"`
+}
+
+// Changed reports whether the effective value differs from the default.
+func (d KeyDiff) Changed() bool {
+	return !reflect.DeepEqual(d.Default, d.Effective)
+}
+
+// Diff reports, for every config key, the default value, the value set by
+// system and global config, the value set by local config, and the
+// effective value after profiles are applied. When a config path override
+// is set, that file is reported as the global layer.
+func (m *ManagerImpl) Diff() ([]KeyDiff, error) {
+	effective, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var globalPaths, localPaths []string
+	if m.configPath != "" {
+		globalPaths = []string{m.configPath}
+	} else {
+		globalPaths = m.userPaths()
+		if localPaths, err = m.LocalPaths(); err != nil {
+			return nil, err
+		}
+	}
+	global, err := layerValues(globalPaths)
+	if err != nil {
+		return nil, err
+	}
+	local, err := layerValues(localPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := configValues(domain.DefaultConfig())
+	effectiveValues := configValues(effective)
+	diffs := make([]KeyDiff, 0, len(defaults))
+	for _, key := range configKeys() {
+		diffs = append(diffs, KeyDiff{
+			Key:       key,
+			Default:   defaults[key],
+			Global:    global[key],
+			Local:     local[key],
+			Effective: effectiveValues[key],
+		})
+	}
+	return diffs, nil
+}
+
+// layerValues returns the keys set by the given files, later files
+// overriding earlier ones.
+func layerValues(paths []string) (map[string]any, error) {
+	layers, err := readLayers(paths)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	for _, partial := range layers {
+		for key, value := range partialValues(partial) {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// partialValues returns the settings a partial sets, keyed by config name.
+func partialValues(partial *partialConfig) map[string]any {
+	values := make(map[string]any)
+	v := reflect.ValueOf(*partial)
+	t := v.Type()
+	settings := configValues(domain.Config{})
+	for i := 0; i < t.NumField(); i++ {
+		key := tagName(t.Field(i))
+		if _, ok := settings[key]; !ok {
+			continue
+		}
+		switch field := v.Field(i); {
+		case field.Kind() == reflect.Pointer && !field.IsNil():
+			values[key] = field.Elem().Interface()
+		case field.Kind() == reflect.Map && field.Len() > 0:
+			values[key] = field.Interface()
+		}
+	}
+	return values
+}
+
+// configValues returns the settings in cfg keyed by config name. The
+// schema version is not a setting and is left out.
+func configValues(cfg domain.Config) map[string]any {
+	values := make(map[string]any)
+	v := reflect.ValueOf(cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if key := tagName(t.Field(i)); key != "" && key != versionKey {
+			values[key] = v.Field(i).Interface()
+		}
+	}
+	return values
+}
+
+// configKeys returns the setting names in declaration order.
+func configKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(domain.Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := tagName(t.Field(i)); key != "" && key != versionKey {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func tagName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("toml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
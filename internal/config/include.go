@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// readLayers reads each config path in order, expanding includes so that
+// included files are applied before the file that includes them.
+func readLayers(paths []string) ([]*partialConfig, error) {
+	var layers []*partialConfig
+	for _, path := range paths {
+		fileLayers, err := readConfigWithIncludes(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, fileLayers...)
+	}
+	return layers, nil
+}
+
+// readConfigWithIncludes reads a config file and its includes depth-first.
+// The stack holds the chain of including files and is used to detect cycles.
+func readConfigWithIncludes(path string, stack []string) ([]*partialConfig, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range stack {
+		if parent == absPath {
+			return nil, fmt.Errorf("config include cycle: %s", strings.Join(append(stack, absPath), " -> "))
+		}
+	}
+	partial, err := readConfig(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", absPath, err)
+	}
+	if partial == nil {
+		return nil, nil
+	}
+	if len(stack) == 0 {
+		// Included fragments are versioned by the file that includes them
+		for _, problem := range schemaWarnings(partial) {
+			partial.warnings = append(partial.warnings, fmt.Sprintf("%s: %s", absPath, problem))
+		}
+	}
+
+	stack = append(stack, absPath)
+	var layers []*partialConfig
+	for _, include := range partial.Include {
+		includePath := resolveIncludePath(absPath, include)
+		if exists, err := fileExists(includePath); err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, fmt.Errorf("%s: included config %q not found", absPath, include)
+		}
+		included, err := readConfigWithIncludes(includePath, stack)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, included...)
+	}
+	return append(layers, partial), nil
+}
+
+// resolveIncludePath expands ~ and environment variables and resolves
+// relative includes against the directory of the including file.
+func resolveIncludePath(from, include string) string {
+	path := expandPath(strings.TrimSpace(include))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+	return filepath.Clean(path)
+}
@@ -2,19 +2,26 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pelletier/go-toml/v2"
 
 	"github.com/go-cli-template/internal/domain"
+	pkg "github.com/go-cli-template/internal/package"
 	"github.com/go-cli-template/internal/utils"
 )
 
 // ManagerImpl loads and saves configuration files.
 type ManagerImpl struct {
-	cwd string
+	cwd        string
+	profile    string
+	configPath string
+	warnings   []string
+	sources    []string
 }
 
 // NewManager returns a config manager rooted at the provided cwd.
@@ -22,41 +29,237 @@ func NewManager(cwd string) *ManagerImpl {
 	return &ManagerImpl{cwd: cwd}
 }
 
+// WithProfile selects a named profile to overlay on the loaded config.
+// An empty name falls back to the profile environment variable.
+func (m *ManagerImpl) WithProfile(name string) *ManagerImpl {
+	m.profile = strings.TrimSpace(name)
+	return m
+}
+
+// WithConfigPath replaces the layered config files with a single explicit
+// file, as selected by --config. An empty path keeps the layered lookup.
+func (m *ManagerImpl) WithConfigPath(path string) *ManagerImpl {
+	m.configPath = strings.TrimSpace(path)
+	return m
+}
+
+// ConfigPath returns the explicit config file path, if any.
+func (m *ManagerImpl) ConfigPath() string {
+	return m.configPath
+}
+
+// ActiveProfile returns the selected profile name, if any.
+func (m *ManagerImpl) ActiveProfile() string {
+	if m.profile != "" {
+		return m.profile
+	}
+	return strings.TrimSpace(os.Getenv(ProfileEnvVar()))
+}
+
+// ProfileEnvVar returns the environment variable used to select a profile.
+func ProfileEnvVar() string {
+	name := strings.ToUpper(strings.ReplaceAll(pkg.Name(), "-", "_"))
+	return name + "_PROFILE"
+}
+
 // LoadWithOverride loads config from a specific path, layered on defaults.
 func (m *ManagerImpl) LoadWithOverride(path string) (domain.Config, error) {
-	config := domain.DefaultConfig()
 	if strings.TrimSpace(path) == "" {
 		return m.Load()
 	}
-	partial, err := readConfig(path)
+	return m.loadPaths([]string{path})
+}
+
+// Load reads config with precedence:
+// defaults < system < global < local (outermost to innermost) < profile.
+func (m *ManagerImpl) Load() (domain.Config, error) {
+	paths, err := m.LayerPaths()
 	if err != nil {
 		return domain.Config{}, err
 	}
-	if partial != nil {
-		applyPartial(&config, partial)
+	config, err := m.loadPaths(paths)
+	if m.configPath == "" {
+		// Also report the cwd's local config so creating one is noticed
+		m.addSource(utils.ConfigPathLocal(m.cwd))
 	}
-	return config, nil
+	return config, err
 }
 
-// Load reads config with precedence: defaults < global < local.
-func (m *ManagerImpl) Load() (domain.Config, error) {
-	config := domain.DefaultConfig()
-
-	globalPath := utils.ConfigPathGlobal()
-	if partial, err := readConfig(globalPath); err != nil {
+// LoadThrough loads the layers up to and including path, leaving out the
+// ones with higher precedence. This is the config as path alone would set
+// it. A path that is not a layer, such as a project config that does not
+// exist yet, is applied on top of the system and global layers.
+func (m *ManagerImpl) LoadThrough(path string) (domain.Config, error) {
+	paths, err := m.LayerPaths()
+	if err != nil {
 		return domain.Config{}, err
-	} else if partial != nil {
-		applyPartial(&config, partial)
 	}
+	through := append(m.userPaths(), path)
+	for i, layer := range paths {
+		if filepath.Clean(layer) == filepath.Clean(path) {
+			through = paths[:i+1]
+			break
+		}
+	}
+	return m.loadPaths(through)
+}
+
+// LocalPaths returns the local config files found from cwd upward, ordered
+// from outermost to innermost. The search boundary is read from the system
+// and global layers via local_config_boundary.
+func (m *ManagerImpl) LocalPaths() ([]string, error) {
+	layers, err := readLayers(m.userPaths())
+	if err != nil {
+		return nil, err
+	}
+	boundary := domain.DefaultConfig().LocalConfigBoundary
+	for _, partial := range layers {
+		if partial.LocalConfigBoundary != nil {
+			boundary = *partial.LocalConfigBoundary
+		}
+	}
+	var paths []string
+	for _, dir := range utils.LocalConfigDirs(m.cwd, boundary) {
+		path, exists, err := FindConfigFile(utils.ConfigPathLocal(dir))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// Profiles returns the sorted names of profiles defined across config layers.
+func (m *ManagerImpl) Profiles() ([]string, error) {
+	paths, err := m.LayerPaths()
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := collectProfiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// userPaths returns the system and global config paths, lowest precedence
+// first, resolved to whichever supported format exists on disk.
+func (m *ManagerImpl) userPaths() []string {
+	paths := append(utils.ConfigPathsSystem(), utils.ConfigPathGlobal())
+	for i, path := range paths {
+		if resolved, exists, err := FindConfigFile(path); err == nil && exists {
+			paths[i] = resolved
+		}
+	}
+	return paths
+}
+
+// LayerPaths returns the system, global and local config file paths (or the
+// explicit config path) from lowest to highest precedence. Theme and included files
+// are not layers and are left out.
+func (m *ManagerImpl) LayerPaths() ([]string, error) {
+	if m.configPath != "" {
+		if exists, err := fileExists(m.configPath); err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, fmt.Errorf("config file %s not found", m.configPath)
+		}
+		return []string{m.configPath}, nil
+	}
+	localPaths, err := m.LocalPaths()
+	if err != nil {
+		return nil, err
+	}
+	return append(m.userPaths(), localPaths...), nil
+}
+
+// Warnings returns non-fatal problems found by the last Load, such as
+// unknown keys or invalid values.
+func (m *ManagerImpl) Warnings() []string {
+	return m.warnings
+}
+
+// Sources returns the files consulted by the last Load, including layer
+// paths that did not exist, in every registered format, and any included
+// files. Watching these is enough to notice every change that would affect
+// the loaded config.
+func (m *ManagerImpl) Sources() []string {
+	return m.sources
+}
+
+// addSource records path as a source. A missing path is recorded with each
+// registered extension, since creating any of them adds the layer.
+func (m *ManagerImpl) addSource(path string) {
+	candidates := []string{path}
+	if exists, _ := fileExists(path); !exists {
+		stem := strings.TrimSuffix(path, filepath.Ext(path))
+		for _, ext := range codecOrder {
+			candidates = append(candidates, stem+ext)
+		}
+	}
+	for _, candidate := range candidates {
+		if !containsString(m.sources, candidate) {
+			m.sources = append(m.sources, candidate)
+		}
+	}
+}
 
-	localPath := utils.ConfigPathLocal(m.cwd)
-	if partial, err := readConfig(localPath); err != nil {
+func (m *ManagerImpl) loadPaths(paths []string) (domain.Config, error) {
+	m.warnings = nil
+	m.sources = nil
+	for _, path := range paths {
+		m.addSource(path)
+	}
+	layers, err := readLayers(paths)
+	if err != nil {
+		return domain.Config{}, err
+	}
+	profiles := make(profileLayers)
+	for _, partial := range layers {
+		if !containsString(m.sources, partial.path) {
+			m.sources = append(m.sources, partial.path)
+		}
+		m.warnings = append(m.warnings, partial.warnings...)
+		profiles.add(partial.Profiles)
+	}
+	config, err := m.applyLayers(domain.DefaultConfig(), layers, profiles)
+	if err != nil {
 		return domain.Config{}, err
-	} else if partial != nil {
-		applyPartial(&config, partial)
+	}
+	if config.Theme == "" || config.Theme == DefaultTheme {
+		return config, nil
 	}
 
-	return config, nil
+	// The theme sits between the defaults and the layers, so resolve it
+	// first and then re-apply the layers so individual color keys win.
+	themed := domain.DefaultConfig()
+	themePath, err := ApplyTheme(&themed, config.Theme)
+	if themePath != "" {
+		m.sources = append(m.sources, themePath)
+	}
+	if err != nil {
+		m.warnings = append(m.warnings, fmt.Sprintf("%v (using default colors)", err))
+		return config, nil
+	}
+	return m.applyLayers(themed, layers, profiles)
+}
+
+// applyLayers applies each layer and then the active profile to base.
+func (m *ManagerImpl) applyLayers(base domain.Config, layers []*partialConfig, profiles profileLayers) (domain.Config, error) {
+	for _, partial := range layers {
+		applyPartial(&base, partial)
+	}
+	if err := profiles.apply(&base, m.ActiveProfile()); err != nil {
+		return domain.Config{}, err
+	}
+	return base, nil
 }
 
 // Save persists config to the global config path.
@@ -74,31 +277,59 @@ func (m *ManagerImpl) Save(config domain.Config) error {
 
 // Exists reports whether a local or global config file exists.
 func (m *ManagerImpl) Exists() (bool, error) {
-	globalPath := utils.ConfigPathGlobal()
-	if exists, err := fileExists(globalPath); err != nil {
+	if _, exists, err := FindConfigFile(utils.ConfigPathGlobal()); err != nil {
 		return false, err
 	} else if exists {
 		return true, nil
 	}
-	localPath := utils.ConfigPathLocal(m.cwd)
-	return fileExists(localPath)
+	_, exists, err := FindConfigFile(utils.ConfigPathLocal(m.cwd))
+	return exists, err
 }
 
 type partialConfig struct {
-	Editor               *string `toml:"editor"`
-	Primary              *string `toml:"primary"`
-	Secondary            *string `toml:"secondary"`
-	Headings             *string `toml:"headings"`
-	Text                 *string `toml:"text"`
-	TextHighlight        *string `toml:"text_highlight"`
-	DescriptionHighlight *string `toml:"description_highlight"`
-	Tags                 *string `toml:"tags"`
-	Flags                *string `toml:"flags"`
-	Muted                *string `toml:"muted"`
-	Accent               *string `toml:"accent"`
-	Border               *string `toml:"border"`
-	InteractiveDefault   *bool   `toml:"interactive_default"`
-	ListSpacing          *string `toml:"list_spacing"`
+	// Version is the schema version the file was written for.
+	Version              *int    `toml:"version" yaml:"version" json:"version"`
+	Editor               *string `toml:"editor" yaml:"editor" json:"editor"`
+	EditorLineTemplate   *string `toml:"editor_line_template" yaml:"editor_line_template" json:"editor_line_template"`
+	EditorLayout         *string `toml:"editor_layout" yaml:"editor_layout" json:"editor_layout"`
+	EditorWait           *bool   `toml:"editor_wait" yaml:"editor_wait" json:"editor_wait"`
+	DiffTool             *string `toml:"diff_tool" yaml:"diff_tool" json:"diff_tool"`
+	Theme                *string `toml:"theme" yaml:"theme" json:"theme"`
+	Primary              *string `toml:"primary" yaml:"primary" json:"primary"`
+	Secondary            *string `toml:"secondary" yaml:"secondary" json:"secondary"`
+	Headings             *string `toml:"headings" yaml:"headings" json:"headings"`
+	Text                 *string `toml:"text" yaml:"text" json:"text"`
+	TextHighlight        *string `toml:"text_highlight" yaml:"text_highlight" json:"text_highlight"`
+	DescriptionHighlight *string `toml:"description_highlight" yaml:"description_highlight" json:"description_highlight"`
+	Tags                 *string `toml:"tags" yaml:"tags" json:"tags"`
+	Flags                *string `toml:"flags" yaml:"flags" json:"flags"`
+	Muted                *string `toml:"muted" yaml:"muted" json:"muted"`
+	Accent               *string `toml:"accent" yaml:"accent" json:"accent"`
+	Border               *string `toml:"border" yaml:"border" json:"border"`
+	Success              *string `toml:"success" yaml:"success" json:"success"`
+	Warning              *string `toml:"warning" yaml:"warning" json:"warning"`
+	Error                *string `toml:"error" yaml:"error" json:"error"`
+	Info                 *string `toml:"info" yaml:"info" json:"info"`
+	SelectionBackground  *string `toml:"selection_background" yaml:"selection_background" json:"selection_background"`
+	FilterMatch          *string `toml:"filter_match" yaml:"filter_match" json:"filter_match"`
+	InteractiveDefault   *bool   `toml:"interactive_default" yaml:"interactive_default" json:"interactive_default"`
+	ListSpacing          *string `toml:"list_spacing" yaml:"list_spacing" json:"list_spacing"`
+	LocalConfigBoundary  *string `toml:"local_config_boundary" yaml:"local_config_boundary" json:"local_config_boundary"`
+	Keymap               *string `toml:"keymap" yaml:"keymap" json:"keymap"`
+	// Keys overrides individual action bindings; it merges across layers.
+	Keys map[string][]string `toml:"keys" yaml:"keys" json:"keys"`
+
+	// Include lists additional config files applied before this file.
+	Include []string `toml:"include" yaml:"include" json:"include"`
+	// Extends names the parent of a profile; it is ignored at the top level.
+	Extends *string `toml:"extends" yaml:"extends" json:"extends"`
+	// Profiles holds named overlays; it is only read at the top level.
+	Profiles map[string]*partialConfig `toml:"profiles" yaml:"profiles" json:"profiles"`
+
+	// path is the file the partial was read from.
+	path string
+	// warnings collects non-fatal problems found while reading the file.
+	warnings []string
 }
 
 func readConfig(path string) (*partialConfig, error) {
@@ -109,17 +340,65 @@ func readConfig(path string) (*partialConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	var partial partialConfig
-	if err := toml.Unmarshal(data, &partial); err != nil {
+	partial, raw, problems, err := decodePartial(CodecFor(path), data)
+	if err != nil {
 		return nil, err
 	}
-	return &partial, nil
+	partial.path = path
+	for _, problem := range problems {
+		partial.warnings = append(partial.warnings, fmt.Sprintf("%s: %s", path, problem))
+	}
+	for _, key := range unknownKeys(raw) {
+		partial.warnings = append(partial.warnings, fmt.Sprintf("%s: unknown key %q", path, key))
+	}
+	for _, problem := range validatePartial(partial) {
+		partial.warnings = append(partial.warnings, fmt.Sprintf("%s: %s", path, problem))
+	}
+	return partial, nil
+}
+
+// decodePartial decodes a config file along with its raw key/value form.
+// Adaptive color tables are converted first; problems converting them are
+// returned rather than failing the whole file.
+func decodePartial(codec Codec, data []byte) (*partialConfig, map[string]any, []string, error) {
+	var raw map[string]any
+	if err := codec.Unmarshal(data, &raw); err != nil {
+		return nil, nil, nil, err
+	}
+	changed, problems := normalizeColors(raw)
+	if changed {
+		normalized, err := codec.Marshal(raw)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		data = normalized
+	}
+	var partial partialConfig
+	if err := codec.Unmarshal(data, &partial); err != nil {
+		return nil, nil, nil, err
+	}
+	return &partial, raw, problems, nil
 }
 
 func applyPartial(config *domain.Config, partial *partialConfig) {
 	if partial.Editor != nil {
 		config.Editor = *partial.Editor
 	}
+	if partial.EditorLineTemplate != nil {
+		config.EditorLineTemplate = *partial.EditorLineTemplate
+	}
+	if partial.EditorLayout != nil {
+		config.EditorLayout = *partial.EditorLayout
+	}
+	if partial.EditorWait != nil {
+		config.EditorWait = *partial.EditorWait
+	}
+	if partial.DiffTool != nil {
+		config.DiffTool = *partial.DiffTool
+	}
+	if partial.Theme != nil {
+		config.Theme = *partial.Theme
+	}
 	if partial.Primary != nil {
 		config.Primary = *partial.Primary
 	}
@@ -153,12 +432,46 @@ func applyPartial(config *domain.Config, partial *partialConfig) {
 	if partial.Border != nil {
 		config.Border = *partial.Border
 	}
+	if partial.Success != nil {
+		config.Success = *partial.Success
+	}
+	if partial.Warning != nil {
+		config.Warning = *partial.Warning
+	}
+	if partial.Error != nil {
+		config.Error = *partial.Error
+	}
+	if partial.Info != nil {
+		config.Info = *partial.Info
+	}
+	if partial.SelectionBackground != nil {
+		config.SelectionBackground = *partial.SelectionBackground
+	}
+	if partial.FilterMatch != nil {
+		config.FilterMatch = *partial.FilterMatch
+	}
 	if partial.InteractiveDefault != nil {
 		config.InteractiveDefault = *partial.InteractiveDefault
 	}
 	if partial.ListSpacing != nil {
 		config.ListSpacing = *partial.ListSpacing
 	}
+	if partial.LocalConfigBoundary != nil {
+		config.LocalConfigBoundary = *partial.LocalConfigBoundary
+	}
+	if partial.Keymap != nil {
+		config.Keymap = *partial.Keymap
+	}
+	if len(partial.Keys) > 0 {
+		keys := make(map[string][]string, len(config.Keys)+len(partial.Keys))
+		for action, bound := range config.Keys {
+			keys[action] = bound
+		}
+		for action, bound := range partial.Keys {
+			keys[action] = bound
+		}
+		config.Keys = keys
+	}
 }
 
 func expandPath(value string) string {
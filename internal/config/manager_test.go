@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-cli-template/internal/domain"
@@ -29,6 +30,27 @@ func TestManagerLoadsDefaults(t *testing.T) {
 	}
 }
 
+func TestManagerSourcesCoverEveryFormat(t *testing.T) {
+	root := t.TempDir()
+	cwd := filepath.Join(root, "project")
+	if err := os.MkdirAll(cwd, 0o755); err != nil {
+		t.Fatalf("mkdir cwd: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+
+	manager := NewManager(cwd)
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	global := strings.TrimSuffix(utils.ConfigPathGlobal(), filepath.Ext(utils.ConfigPathGlobal()))
+	for _, ext := range Extensions() {
+		if !containsString(manager.Sources(), global+ext) {
+			t.Errorf("expected %s in sources, got %v", global+ext, manager.Sources())
+		}
+	}
+}
+
 func TestManagerLoadsFromFile(t *testing.T) {
 	root := t.TempDir()
 	cwd := filepath.Join(root, "project")
@@ -240,6 +262,14 @@ func TestManagerLocalOverridesGlobal(t *testing.T) {
 	if cfg.Primary != "01" {
 		t.Errorf("expected primary from global config, got %q", cfg.Primary)
 	}
+
+	cfg, err = manager.LoadThrough(globalPath)
+	if err != nil {
+		t.Fatalf("load through global: %v", err)
+	}
+	if cfg.Editor != "vim" || cfg.Primary != "01" {
+		t.Errorf("expected only the global layer to apply, got editor %q and primary %q", cfg.Editor, cfg.Primary)
+	}
 }
 
 func TestManagerPartialConfig(t *testing.T) {
@@ -275,7 +305,7 @@ func TestManagerPartialConfig(t *testing.T) {
 		if cfg.Primary != "02" {
 			t.Errorf("expected default primary, got %q", cfg.Primary)
 		}
-		if cfg.Headings != "15" {
+		if cfg.Headings != domain.DefaultConfig().Headings {
 			t.Errorf("expected default headings, got %q", cfg.Headings)
 		}
 		if !cfg.InteractiveDefault {
@@ -373,3 +403,538 @@ func TestManagerBooleanOverrides(t *testing.T) {
 		t.Error("expected interactive_default to be false from config")
 	}
 }
+
+func TestManagerProfiles(t *testing.T) {
+	writeGlobal := func(t *testing.T, root, content string) string {
+		t.Helper()
+		cwd := filepath.Join(root, "project")
+		if err := os.MkdirAll(cwd, 0o755); err != nil {
+			t.Fatalf("mkdir cwd: %v", err)
+		}
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+		t.Setenv(ProfileEnvVar(), "")
+
+		configPath := utils.ConfigPathGlobal()
+		if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+			t.Fatalf("mkdir config dir: %v", err)
+		}
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		return cwd
+	}
+
+	data := `
+editor = "vim"
+primary = "02"
+
+[profiles.base]
+text = "00"
+
+[profiles.light]
+extends = "base"
+primary = "04"
+
+[profiles.loop-a]
+extends = "loop-b"
+
+[profiles.loop-b]
+extends = "loop-a"
+`
+
+	t.Run("applies selected profile with inheritance", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+
+		cfg, err := NewManager(cwd).WithProfile("light").Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Primary != "04" {
+			t.Errorf("expected primary from profile, got %q", cfg.Primary)
+		}
+		if cfg.Text != "00" {
+			t.Errorf("expected text from parent profile, got %q", cfg.Text)
+		}
+		if cfg.Editor != "vim" {
+			t.Errorf("expected editor from base config, got %q", cfg.Editor)
+		}
+	})
+
+	t.Run("ignores profiles when none selected", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Primary != "02" {
+			t.Errorf("expected base primary, got %q", cfg.Primary)
+		}
+	})
+
+	t.Run("selects profile from environment", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+		t.Setenv(ProfileEnvVar(), "light")
+
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Primary != "04" {
+			t.Errorf("expected primary from env profile, got %q", cfg.Primary)
+		}
+	})
+
+	t.Run("errors on unknown profile", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+
+		if _, err := NewManager(cwd).WithProfile("missing").Load(); err == nil {
+			t.Fatal("expected error for unknown profile")
+		}
+	})
+
+	t.Run("errors on extends cycle", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+
+		if _, err := NewManager(cwd).WithProfile("loop-a").Load(); err == nil {
+			t.Fatal("expected error for extends cycle")
+		}
+	})
+
+	t.Run("lists profiles", func(t *testing.T) {
+		cwd := writeGlobal(t, t.TempDir(), data)
+
+		names, err := NewManager(cwd).Profiles()
+		if err != nil {
+			t.Fatalf("profiles: %v", err)
+		}
+		expected := []string{"base", "light", "loop-a", "loop-b"}
+		if len(names) != len(expected) {
+			t.Fatalf("Profiles() = %v, want %v", names, expected)
+		}
+		for i := range expected {
+			if names[i] != expected[i] {
+				t.Errorf("Profiles()[%d] = %q, want %q", i, names[i], expected[i])
+			}
+		}
+	})
+}
+
+func TestManagerIncludes(t *testing.T) {
+	setup := func(t *testing.T) (root, cwd, globalPath string) {
+		t.Helper()
+		root = t.TempDir()
+		cwd = filepath.Join(root, "project")
+		if err := os.MkdirAll(cwd, 0o755); err != nil {
+			t.Fatalf("mkdir cwd: %v", err)
+		}
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+		t.Setenv("XDG_CONFIG_DIRS", filepath.Join(root, "system"))
+		globalPath = utils.ConfigPathGlobal()
+		if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+			t.Fatalf("mkdir config dir: %v", err)
+		}
+		return root, cwd, globalPath
+	}
+
+	t.Run("applies included files before the including file", func(t *testing.T) {
+		root, cwd, globalPath := setup(t)
+		t.Setenv("HOME", root)
+
+		sharedPath := filepath.Join(root, ".config", "shared", "theme.toml")
+		if err := os.MkdirAll(filepath.Dir(sharedPath), 0o755); err != nil {
+			t.Fatalf("mkdir shared dir: %v", err)
+		}
+		if err := os.WriteFile(sharedPath, []byte("primary = \"04\"\neditor = \"emacs\"\n"), 0o644); err != nil {
+			t.Fatalf("write shared config: %v", err)
+		}
+		data := []byte("include = [\"~/.config/shared/theme.toml\"]\neditor = \"vim\"\n")
+		if err := os.WriteFile(globalPath, data, 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Primary != "04" {
+			t.Errorf("expected primary from include, got %q", cfg.Primary)
+		}
+		if cfg.Editor != "vim" {
+			t.Errorf("expected including file to override include, got %q", cfg.Editor)
+		}
+	})
+
+	t.Run("does not check the schema version of included files", func(t *testing.T) {
+		_, cwd, globalPath := setup(t)
+
+		colorsPath := filepath.Join(filepath.Dir(globalPath), "colors.toml")
+		if err := os.WriteFile(colorsPath, []byte("version = 0\nmuted = \"03\"\n"), 0o644); err != nil {
+			t.Fatalf("write colors config: %v", err)
+		}
+		if err := os.WriteFile(globalPath, []byte("version = 1\ninclude = [\"colors.toml\"]\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+
+		manager := NewManager(cwd)
+		if _, err := manager.Load(); err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if warnings := manager.Warnings(); len(warnings) > 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("resolves relative includes against the including file", func(t *testing.T) {
+		_, cwd, globalPath := setup(t)
+
+		colorsPath := filepath.Join(filepath.Dir(globalPath), "colors.toml")
+		if err := os.WriteFile(colorsPath, []byte("muted = \"03\"\n"), 0o644); err != nil {
+			t.Fatalf("write colors config: %v", err)
+		}
+		if err := os.WriteFile(globalPath, []byte("include = [\"colors.toml\"]\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Muted != "03" {
+			t.Errorf("expected muted from relative include, got %q", cfg.Muted)
+		}
+	})
+
+	t.Run("detects include cycles", func(t *testing.T) {
+		_, cwd, globalPath := setup(t)
+
+		otherPath := filepath.Join(filepath.Dir(globalPath), "other.toml")
+		if err := os.WriteFile(otherPath, []byte("include = [\"config.toml\"]\n"), 0o644); err != nil {
+			t.Fatalf("write other config: %v", err)
+		}
+		if err := os.WriteFile(globalPath, []byte("include = [\"other.toml\"]\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+
+		if _, err := NewManager(cwd).Load(); err == nil {
+			t.Fatal("expected error for include cycle")
+		}
+	})
+
+	t.Run("errors on missing include", func(t *testing.T) {
+		_, cwd, globalPath := setup(t)
+
+		if err := os.WriteFile(globalPath, []byte("include = [\"missing.toml\"]\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+
+		if _, err := NewManager(cwd).Load(); err == nil {
+			t.Fatal("expected error for missing include")
+		}
+	})
+}
+
+func TestManagerSystemConfig(t *testing.T) {
+	root := t.TempDir()
+	cwd := filepath.Join(root, "project")
+	if err := os.MkdirAll(cwd, 0o755); err != nil {
+		t.Fatalf("mkdir cwd: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	preferred := filepath.Join(root, "preferred")
+	fallback := filepath.Join(root, "fallback")
+	t.Setenv("XDG_CONFIG_DIRS", preferred+string(os.PathListSeparator)+fallback)
+
+	writeSystem := func(dir, content string) {
+		path := filepath.Join(dir, "go-cli-template", "config.toml")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir system dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write system config: %v", err)
+		}
+	}
+	writeSystem(fallback, "editor = \"nano\"\nprimary = \"01\"\nmuted = \"01\"\n")
+	writeSystem(preferred, "primary = \"03\"\nmuted = \"03\"\n")
+
+	globalPath := utils.ConfigPathGlobal()
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	if err := os.WriteFile(globalPath, []byte("muted = \"05\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := NewManager(cwd).Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Editor != "nano" {
+		t.Errorf("expected editor from fallback system dir, got %q", cfg.Editor)
+	}
+	if cfg.Primary != "03" {
+		t.Errorf("expected preferred system dir to win, got %q", cfg.Primary)
+	}
+	if cfg.Muted != "05" {
+		t.Errorf("expected global config to override system, got %q", cfg.Muted)
+	}
+}
+
+func TestManagerNestedLocalConfigs(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	repo := filepath.Join(root, "repo")
+	cwd := filepath.Join(repo, "pkg", "sub")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	if err := os.MkdirAll(cwd, 0o755); err != nil {
+		t.Fatalf("mkdir cwd: %v", err)
+	}
+
+	writeLocal := func(dir, content string) {
+		path := utils.ConfigPathLocal(dir)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir local config dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write local config: %v", err)
+		}
+	}
+	writeLocal(root, "muted = \"01\"\n")
+	writeLocal(repo, "editor = \"vim\"\nprimary = \"03\"\n")
+	writeLocal(filepath.Join(repo, "pkg"), "primary = \"04\"\n")
+
+	t.Run("merges from outermost to innermost within the repository", func(t *testing.T) {
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Editor != "vim" {
+			t.Errorf("expected editor from repository root, got %q", cfg.Editor)
+		}
+		if cfg.Primary != "04" {
+			t.Errorf("expected innermost config to win, got %q", cfg.Primary)
+		}
+		if cfg.Muted != "08" {
+			t.Errorf("expected search to stop at git root, got muted %q", cfg.Muted)
+		}
+	})
+
+	t.Run("honors local_config_boundary from global config", func(t *testing.T) {
+		globalPath := utils.ConfigPathGlobal()
+		if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+			t.Fatalf("mkdir config dir: %v", err)
+		}
+		if err := os.WriteFile(globalPath, []byte("local_config_boundary = \"home\"\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		t.Cleanup(func() { _ = os.Remove(globalPath) })
+
+		cfg, err := NewManager(cwd).Load()
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if cfg.Muted != "01" {
+			t.Errorf("expected search to continue to $HOME, got muted %q", cfg.Muted)
+		}
+	})
+}
+
+func TestManagerAdaptiveColors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"toml", "config.toml", "version = 1\nprimary = { light = \"#005f00\", dark = \"10\" }\nmuted = \"08|07\"\nborder = { light = \"1\", shade = \"2\" }\n[profiles.x]\ntext = { dark = \"15\" }\n"},
+		{"yaml", "config.yaml", "version: 1\nprimary:\n  light: \"#005f00\"\n  dark: 10\nmuted: \"08|07\"\nborder:\n  light: \"1\"\n  shade: \"2\"\nprofiles:\n  x:\n    text:\n      dark: \"15\"\n"},
+		{"json", "config.json", `{"version": 1, "primary": {"light": "#005f00", "dark": "10"}, "muted": "08|07", "border": {"light": "1", "shade": "2"}, "profiles": {"x": {"text": {"dark": "15"}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			cwd := filepath.Join(root, "project")
+			if err := os.MkdirAll(cwd, 0o755); err != nil {
+				t.Fatalf("mkdir cwd: %v", err)
+			}
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+
+			configPath := filepath.Join(filepath.Dir(utils.ConfigPathGlobal()), tt.file)
+			if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+				t.Fatalf("mkdir config dir: %v", err)
+			}
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			manager := NewManager(cwd).WithProfile("x")
+			cfg, err := manager.Load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if cfg.Primary != "#005f00|10" {
+				t.Errorf("expected adaptive primary, got %q", cfg.Primary)
+			}
+			if cfg.Muted != "08|07" {
+				t.Errorf("expected adaptive muted, got %q", cfg.Muted)
+			}
+			if cfg.Text != "|15" {
+				t.Errorf("expected adaptive text from profile, got %q", cfg.Text)
+			}
+			if cfg.Border != domain.DefaultConfig().Border {
+				t.Errorf("expected invalid border table to be ignored, got %q", cfg.Border)
+			}
+			warnings := strings.Join(manager.Warnings(), "\n")
+			if !strings.Contains(warnings, `border: unknown key "shade"`) {
+				t.Errorf("expected border warning, got %q", warnings)
+			}
+		})
+	}
+}
+
+func TestManagerConfigFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "config.yaml", "version: 1\neditor: helix\nprimary: \"04\"\nprofiles:\n  dark:\n    muted: \"01\"\n"},
+		{"yml", "config.yml", "version: 1\neditor: helix\nprimary: \"04\"\n"},
+		{"json", "config.json", "{\"version\": 1, \"editor\": \"helix\", \"primary\": \"04\"}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			cwd := filepath.Join(root, "project")
+			if err := os.MkdirAll(cwd, 0o755); err != nil {
+				t.Fatalf("mkdir cwd: %v", err)
+			}
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+
+			configPath := filepath.Join(filepath.Dir(utils.ConfigPathGlobal()), tt.file)
+			if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+				t.Fatalf("mkdir config dir: %v", err)
+			}
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			manager := NewManager(cwd)
+			cfg, err := manager.Load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if cfg.Editor != "helix" {
+				t.Errorf("expected editor from %s config, got %q", tt.name, cfg.Editor)
+			}
+			if cfg.Primary != "04" {
+				t.Errorf("expected primary from %s config, got %q", tt.name, cfg.Primary)
+			}
+			if len(manager.Warnings()) != 0 {
+				t.Errorf("expected no warnings, got %v", manager.Warnings())
+			}
+		})
+	}
+}
+
+func TestManagerWarnings(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"toml", "config.toml", "editor = \"vim\"\ncolour = \"01\"\nlist_spacing = \"roomy\"\neditor_line_template = \"hx '{file}\"\n"},
+		{"yaml", "config.yaml", "editor: vim\ncolour: \"01\"\nlist_spacing: roomy\neditor_line_template: \"hx '{file}\"\n"},
+		{"json", "config.json", "{\"editor\": \"vim\", \"colour\": \"01\", \"list_spacing\": \"roomy\", \"editor_line_template\": \"hx '{file}\"}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			cwd := filepath.Join(root, "project")
+			if err := os.MkdirAll(cwd, 0o755); err != nil {
+				t.Fatalf("mkdir cwd: %v", err)
+			}
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+
+			configPath := filepath.Join(filepath.Dir(utils.ConfigPathGlobal()), tt.file)
+			if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+				t.Fatalf("mkdir config dir: %v", err)
+			}
+			if err := os.WriteFile(configPath, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			manager := NewManager(cwd)
+			cfg, err := manager.Load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if cfg.Editor != "vim" {
+				t.Errorf("expected known keys to still load, got editor %q", cfg.Editor)
+			}
+			warnings := strings.Join(manager.Warnings(), "\n")
+			if !strings.Contains(warnings, `unknown key "colour"`) {
+				t.Errorf("expected unknown key warning, got %q", warnings)
+			}
+			if !strings.Contains(warnings, "list_spacing") {
+				t.Errorf("expected list_spacing warning, got %q", warnings)
+			}
+			if !strings.Contains(warnings, "editor_line_template") {
+				t.Errorf("expected editor_line_template warning, got %q", warnings)
+			}
+			if strings.Contains(warnings, "schema version") {
+				t.Errorf("expected no schema version warning without a version, got %q", warnings)
+			}
+		})
+	}
+}
+
+func TestManagerKeys(t *testing.T) {
+	root := t.TempDir()
+	cwd := filepath.Join(root, "project")
+	if err := os.MkdirAll(filepath.Join(cwd, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir cwd: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+
+	globalPath := utils.ConfigPathGlobal()
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	global := "version = 1\nkeymap = \"vim\"\n\n[keys]\ndelete = [\"x\"]\nopen = [\"e\"]\n"
+	if err := os.WriteFile(globalPath, []byte(global), 0o644); err != nil {
+		t.Fatalf("write global config: %v", err)
+	}
+	localPath := utils.ConfigPathLocal(cwd)
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		t.Fatalf("mkdir local dir: %v", err)
+	}
+	local := "version = 1\n\n[keys]\nopen = [\"o\"]\nlaunch = [\"l\"]\n"
+	if err := os.WriteFile(localPath, []byte(local), 0o644); err != nil {
+		t.Fatalf("write local config: %v", err)
+	}
+
+	manager := NewManager(cwd)
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Keymap != "vim" {
+		t.Errorf("expected keymap vim, got %q", cfg.Keymap)
+	}
+	if got := cfg.Keys["delete"]; len(got) != 1 || got[0] != "x" {
+		t.Errorf("expected delete from global config, got %v", got)
+	}
+	if got := cfg.Keys["open"]; len(got) != 1 || got[0] != "o" {
+		t.Errorf("expected local open to override global, got %v", got)
+	}
+	warnings := strings.Join(manager.Warnings(), "\n")
+	if !strings.Contains(warnings, "keys.launch") {
+		t.Errorf("expected unknown action warning, got %q", warnings)
+	}
+}
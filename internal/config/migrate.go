@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/utils"
+)
+
+// versionKey is the config key that records a file's schema version.
+const versionKey = "version"
+
+// Migration upgrades a raw config document from schema version From to
+// From+1. Apply edits raw in place; the version key is updated afterwards.
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(raw map[string]any) error
+}
+
+// migrations holds the registered upgrades, keyed by the version they
+// start from.
+var migrations = map[int]Migration{}
+
+func init() {
+	// Files written before versioning have no version key but otherwise
+	// match schema 1.
+	RegisterMigration(Migration{
+		From:        0,
+		Description: "record the schema version",
+		Apply:       func(map[string]any) error { return nil },
+	})
+}
+
+// RegisterMigration adds an upgrade step. It panics if a step for the same
+// version is already registered.
+func RegisterMigration(migration Migration) {
+	if _, exists := migrations[migration.From]; exists {
+		panic(fmt.Sprintf("config: migration from version %d registered twice", migration.From))
+	}
+	migrations[migration.From] = migration
+}
+
+// MigrationResult describes an upgrade of a single file.
+type MigrationResult struct {
+	Path string
+	From int
+	To   int
+	// Applied lists the description of each step that ran.
+	Applied []string
+	// Diff is a unified diff of the file contents; empty when up to date.
+	Diff string
+	// Backup is the copy of the original file; empty for dry runs.
+	Backup string
+}
+
+// Changed reports whether the file needed migrating.
+func (r MigrationResult) Changed() bool {
+	return r.From != r.To
+}
+
+// MigrateFile upgrades the config file at path to domain.ConfigVersion,
+// one registered step at a time. Unless dryRun is set, the original is
+// copied to <path>.v<from>.bak before the new content is written.
+func MigrateFile(path string, dryRun bool) (MigrationResult, error) {
+	result := MigrationResult{Path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+	codec := CodecFor(path)
+	raw := make(map[string]any)
+	if err := codec.Unmarshal(data, &raw); err != nil {
+		return result, fmt.Errorf("%s: %w", path, err)
+	}
+	from, err := schemaVersion(raw)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", path, err)
+	}
+	result.From, result.To = from, from
+	if from > domain.ConfigVersion {
+		return result, fmt.Errorf("%s: schema version %d is newer than the supported version %d", path, from, domain.ConfigVersion)
+	}
+	if from == domain.ConfigVersion {
+		return result, nil
+	}
+
+	migrated := copyDocument(raw)
+	for version := from; version < domain.ConfigVersion; version++ {
+		migration, ok := migrations[version]
+		if !ok {
+			return result, fmt.Errorf("no migration registered from schema version %d", version)
+		}
+		if err := migration.Apply(migrated); err != nil {
+			return result, fmt.Errorf("%s: migrate from version %d: %w", path, version, err)
+		}
+		migrated[versionKey] = version + 1
+		result.Applied = append(result.Applied, migration.Description)
+	}
+	result.To = domain.ConfigVersion
+
+	updated, err := renderMigrated(path, data, raw, migrated)
+	if err != nil {
+		return result, err
+	}
+	result.Diff = utils.UnifiedDiff(path, path, string(data), string(updated))
+	if dryRun {
+		return result, nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := os.WriteFile(backup, data, 0o644); err != nil {
+		return result, err
+	}
+	result.Backup = backup
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Versioned reports whether the config file at path sets a schema version.
+func Versioned(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	raw := make(map[string]any)
+	if err := CodecFor(path).Unmarshal(data, &raw); err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	_, ok := raw[versionKey]
+	return ok, nil
+}
+
+// schemaVersion reads the version key, treating a missing key as 0.
+func schemaVersion(raw map[string]any) (int, error) {
+	value, ok := raw[versionKey]
+	if !ok {
+		return 0, nil
+	}
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("version must be a whole number, got %v", value)
+}
+
+// renderMigrated produces the new file content. When a migration only sets
+// top-level values, TOML and YAML files are patched line by line so comments
+// survive; anything else is re-encoded.
+func renderMigrated(path string, data []byte, before, after map[string]any) ([]byte, error) {
+	if values, ok := topLevelSets(before, after); ok {
+		switch CodecFor(path) {
+		case CodecFor(".yaml"):
+			return patchLines(data, values, yamlSyntax)
+		case CodecFor(".toml"):
+			return patchLines(data, values, tomlSyntax)
+		}
+	}
+	return CodecFor(path).Marshal(after)
+}
+
+// topLevelSets returns the scalar values that changed between before and
+// after, or false when a key was removed or a nested value changed.
+func topLevelSets(before, after map[string]any) (map[string]any, bool) {
+	values := make(map[string]any)
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			return nil, false
+		}
+	}
+	for key, value := range after {
+		if previous, ok := before[key]; ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+		if _, err := formatScalar(value); err != nil {
+			return nil, false
+		}
+		values[key] = value
+	}
+	return values, true
+}
+
+// copyDocument deep-copies a decoded document so migrations cannot modify
+// the original used for diffing.
+func copyDocument(raw map[string]any) map[string]any {
+	copied := make(map[string]any, len(raw))
+	for key, value := range raw {
+		copied[key] = copyValue(value)
+	}
+	return copied
+}
+
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return copyDocument(v)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = copyValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// schemaWarnings reports a file whose schema version differs from the
+// current one. Files without a version are not reported, since most hand
+// written configs and included fragments never set one.
+func schemaWarnings(partial *partialConfig) []string {
+	if partial.Version == nil {
+		return nil
+	}
+	version := *partial.Version
+	switch {
+	case version < domain.ConfigVersion:
+		return []string{fmt.Sprintf("config schema version %d is older than %d; run `config migrate` to upgrade", version, domain.ConfigVersion)}
+	case version > domain.ConfigVersion:
+		return []string{fmt.Sprintf("config schema version %d is newer than the supported version %d", version, domain.ConfigVersion)}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"toml", "config.toml", "# my editor\neditor = \"vim\"\n\n[profiles.dark]\nmuted = \"01\"\n", "# my editor\neditor = \"vim\"\nversion = 1\n\n[profiles.dark]\nmuted = \"01\"\n"},
+		{"yaml", "config.yaml", "# my editor\neditor: vim\n", "# my editor\neditor: vim\nversion: 1\n"},
+		{"json", "config.json", "{\"editor\": \"vim\"}\n", "{\n  \"editor\": \"vim\",\n  \"version\": 1\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			result, err := MigrateFile(path, true)
+			if err != nil {
+				t.Fatalf("dry run: %v", err)
+			}
+			if !result.Changed() || result.From != 0 || result.To != 1 {
+				t.Errorf("expected migration from 0 to 1, got %d to %d", result.From, result.To)
+			}
+			if !strings.Contains(result.Diff, "+") || result.Backup != "" {
+				t.Errorf("expected diff and no backup for dry run, got %+v", result)
+			}
+			if data, _ := os.ReadFile(path); string(data) != tt.content {
+				t.Errorf("expected dry run to leave file untouched, got:\n%s", data)
+			}
+
+			result, err = MigrateFile(path, false)
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read migrated config: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("migrated config:\n%s\nwant:\n%s", data, tt.want)
+			}
+			backup, err := os.ReadFile(path + ".v0.bak")
+			if err != nil || string(backup) != tt.content {
+				t.Errorf("expected backup of original at %s, got %q (%v)", result.Backup, backup, err)
+			}
+
+			result, err = MigrateFile(path, false)
+			if err != nil {
+				t.Fatalf("second migrate: %v", err)
+			}
+			if result.Changed() {
+				t.Error("expected migrated file to be up to date")
+			}
+		})
+	}
+
+	t.Run("rejects newer versions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte("version = 99\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		if _, err := MigrateFile(path, true); err == nil {
+			t.Error("expected error for a newer schema version")
+		}
+	})
+}
+
+func TestSchemaWarnings(t *testing.T) {
+	older, current, newer := 0, 1, 99
+	tests := []struct {
+		name    string
+		version *int
+		want    string
+	}{
+		{"missing", nil, ""},
+		{"older", &older, "older"},
+		{"current", &current, ""},
+		{"newer", &newer, "newer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := strings.Join(schemaWarnings(&partialConfig{Version: tt.version}), "\n")
+			if tt.want == "" && warnings != "" {
+				t.Errorf("expected no warning, got %q", warnings)
+			}
+			if !strings.Contains(warnings, tt.want) {
+				t.Errorf("expected %q warning, got %q", tt.want, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+// ChangedValues returns the config keys whose values differ between before
+// and after, keyed by their config file name.
+func ChangedValues(before, after domain.Config) map[string]any {
+	changes := make(map[string]any)
+	beforeValue := reflect.ValueOf(before)
+	afterValue := reflect.ValueOf(after)
+	t := beforeValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("toml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		if !reflect.DeepEqual(beforeValue.Field(i).Interface(), afterValue.Field(i).Interface()) {
+			changes[key] = afterValue.Field(i).Interface()
+		}
+	}
+	return changes
+}
+
+// SetValues writes top-level values into the config file at path, creating
+// it when missing. TOML and YAML files are edited line by line so comments
+// and unrelated keys are preserved: an existing key is updated in place, a
+// commented-out key (as written by config init) is uncommented, and any
+// other key is added. JSON files are re-encoded.
+func SetValues(path string, values map[string]any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var updated []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		updated, err = patchLines(data, values, yamlSyntax)
+	case ".json":
+		updated, err = patchEncoded(path, data, values)
+	default:
+		updated, err = patchLines(data, values, tomlSyntax)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, updated, 0o644)
+}
+
+// lineSyntax describes how a top-level key is written in a line-based format.
+type lineSyntax struct {
+	separator string
+	// indent matches whitespace allowed before a top-level key.
+	indent string
+	// topLevelEnd returns the index of the first line that is no longer
+	// part of the top-level table.
+	topLevelEnd func(lines []string) int
+}
+
+var tomlSyntax = lineSyntax{
+	separator: " = ",
+	indent:    `\s*`,
+	topLevelEnd: func(lines []string) int {
+		for i, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "[") {
+				return i
+			}
+		}
+		return len(lines)
+	},
+}
+
+var yamlSyntax = lineSyntax{
+	separator: ": ",
+	indent:    "",
+	topLevelEnd: func(lines []string) int {
+		return len(lines)
+	},
+}
+
+// scalarPattern matches a simple scalar value followed by an optional comment.
+const scalarPattern = `("(?:[^"\\]|\\.)*"|'[^']*'|[^\s#]+)(\s+#.*)?$`
+
+func patchLines(data []byte, values map[string]any, syntax lineSyntax) ([]byte, error) {
+	content := strings.TrimRight(string(data), "\n")
+	var lines []string
+	if content != "" {
+		lines = strings.Split(content, "\n")
+	}
+
+	sep := strings.TrimSpace(syntax.separator)
+	for _, key := range sortedKeys(values) {
+		literal, err := formatScalar(values[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		quotedKey := regexp.QuoteMeta(key)
+		active := regexp.MustCompile(`^(` + syntax.indent + quotedKey + `\s*` + regexp.QuoteMeta(sep) + `\s*)` + scalarPattern)
+		activeLoose := regexp.MustCompile(`^` + syntax.indent + quotedKey + `\s*` + regexp.QuoteMeta(sep))
+		commented := regexp.MustCompile(`^` + syntax.indent + `#\s*` + quotedKey + `\s*` + regexp.QuoteMeta(sep))
+		line := key + syntax.separator + literal
+
+		end := syntax.topLevelEnd(lines)
+		replaced := false
+		for i := 0; i < end && !replaced; i++ {
+			if match := active.FindStringSubmatch(lines[i]); match != nil {
+				lines[i] = match[1] + literal + match[3]
+				replaced = true
+			} else if activeLoose.MatchString(lines[i]) {
+				lines[i] = line
+				replaced = true
+			}
+		}
+		for i := 0; i < end && !replaced; i++ {
+			if commented.MatchString(lines[i]) {
+				lines[i] = line
+				replaced = true
+			}
+		}
+		if !replaced {
+			insertAt := end
+			for insertAt > 0 && strings.TrimSpace(lines[insertAt-1]) == "" {
+				insertAt--
+			}
+			lines = append(lines[:insertAt], append([]string{line}, lines[insertAt:]...)...)
+		}
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func patchEncoded(path string, data []byte, values map[string]any) ([]byte, error) {
+	codec := CodecFor(path)
+	raw := make(map[string]any)
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := codec.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for key, value := range values {
+		raw[key] = value
+	}
+	return codec.Marshal(raw)
+}
+
+// formatScalar renders a value as a literal valid in both TOML and YAML.
+func formatScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
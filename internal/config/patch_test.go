@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestChangedValues(t *testing.T) {
+	before := domain.DefaultConfig()
+	after := before
+	after.Primary = "04"
+	after.InteractiveDefault = false
+
+	changes := ChangedValues(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("ChangedValues() = %v, want 2 changes", changes)
+	}
+	if changes["primary"] != "04" {
+		t.Errorf("changes[primary] = %v, want %q", changes["primary"], "04")
+	}
+	if changes["interactive_default"] != false {
+		t.Errorf("changes[interactive_default] = %v, want false", changes["interactive_default"])
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		existing string
+		values   map[string]any
+		want     string
+	}{
+		{
+			name:     "updates toml key in place and keeps comments",
+			file:     "config.toml",
+			existing: "# My config\neditor = \"vim\" # favourite\nprimary = \"02\"\n",
+			values:   map[string]any{"editor": "hx"},
+			want:     "# My config\neditor = \"hx\" # favourite\nprimary = \"02\"\n",
+		},
+		{
+			name:     "uncomments template keys",
+			file:     "config.toml",
+			existing: "# Colors\n# primary = \"02\"\n",
+			values:   map[string]any{"primary": "04"},
+			want:     "# Colors\nprimary = \"04\"\n",
+		},
+		{
+			name:     "adds new toml keys before tables",
+			file:     "config.toml",
+			existing: "editor = \"vim\"\n\n[profiles.dark]\nprimary = \"01\"\n",
+			values:   map[string]any{"primary": "04", "interactive_default": false},
+			want:     "editor = \"vim\"\ninteractive_default = false\nprimary = \"04\"\n\n[profiles.dark]\nprimary = \"01\"\n",
+		},
+		{
+			name:   "creates missing files",
+			file:   "config.toml",
+			values: map[string]any{"editor": "hx"},
+			want:   "editor = \"hx\"\n",
+		},
+		{
+			name:     "updates top-level yaml keys only",
+			file:     "config.yaml",
+			existing: "# YAML\nprimary: \"02\"\nprofiles:\n  dark:\n    primary: \"01\"\n",
+			values:   map[string]any{"primary": "04"},
+			want:     "# YAML\nprimary: \"04\"\nprofiles:\n  dark:\n    primary: \"01\"\n",
+		},
+		{
+			name:     "re-encodes json",
+			file:     "config.json",
+			existing: "{\"editor\": \"vim\"}\n",
+			values:   map[string]any{"editor": "hx"},
+			want:     "{\n  \"editor\": \"hx\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0o644); err != nil {
+					t.Fatalf("write config: %v", err)
+				}
+			}
+
+			if err := SetValues(path, tt.values); err != nil {
+				t.Fatalf("SetValues: %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read config: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("SetValues() wrote:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
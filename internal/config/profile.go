@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+// profileLayers groups profile definitions by name in layer order, so a
+// profile defined in both global and local config is applied global first.
+type profileLayers map[string][]*partialConfig
+
+func (p profileLayers) add(profiles map[string]*partialConfig) {
+	for name, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+		p[name] = append(p[name], profile)
+	}
+}
+
+// extends returns the parent profile name, honoring the last layer that sets it.
+func (p profileLayers) extends(name string) string {
+	parent := ""
+	for _, layer := range p[name] {
+		if layer.Extends != nil {
+			parent = strings.TrimSpace(*layer.Extends)
+		}
+	}
+	return parent
+}
+
+// chain resolves a profile and its ancestors, ordered from the root ancestor
+// to the requested profile.
+func (p profileLayers) chain(name string) ([]string, error) {
+	var chain []string
+	seen := make(map[string]bool)
+	for current := name; current != ""; current = p.extends(current) {
+		if _, ok := p[current]; !ok {
+			if current == name {
+				return nil, fmt.Errorf("unknown profile %q", current)
+			}
+			return nil, fmt.Errorf("profile %q extends unknown profile %q", chain[len(chain)-1], current)
+		}
+		if seen[current] {
+			return nil, fmt.Errorf("profile %q has an extends cycle through %q", name, current)
+		}
+		seen[current] = true
+		chain = append(chain, current)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// apply overlays the named profile, including its ancestors, onto config.
+func (p profileLayers) apply(config *domain.Config, name string) error {
+	if name == "" {
+		return nil
+	}
+	chain, err := p.chain(name)
+	if err != nil {
+		return err
+	}
+	for _, profile := range chain {
+		for _, layer := range p[profile] {
+			applyPartial(config, layer)
+		}
+	}
+	return nil
+}
+
+func collectProfiles(paths []string) (profileLayers, error) {
+	layers, err := readLayers(paths)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make(profileLayers)
+	for _, partial := range layers {
+		profiles.add(partial.Profiles)
+	}
+	return profiles, nil
+}
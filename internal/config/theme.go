@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/utils"
+)
+
+// DefaultTheme names the built-in theme matching the default colors.
+const DefaultTheme = "default"
+
+// ThemeInfo describes an available theme.
+type ThemeInfo struct {
+	Name string `json:"name"`
+	// Path is the theme file; empty for built-in themes.
+	Path string `json:"path,omitempty"`
+}
+
+// BuiltIn reports whether the theme ships with the binary.
+func (t ThemeInfo) BuiltIn() bool {
+	return t.Path == ""
+}
+
+// base16Roles maps color keys onto Base16 palette slots following the
+// Base16 styling guidelines. Base24 schemes prefer the bright variants in
+// base24Roles where they exist.
+var base16Roles = map[string]string{
+	"headings":              "base0D",
+	"primary":               "base0B",
+	"secondary":             "base0C",
+	"text":                  "base05",
+	"text_highlight":        "base0C",
+	"description_highlight": "base0E",
+	"tags":                  "base0E",
+	"flags":                 "base0D",
+	"muted":                 "base03",
+	"accent":                "base0E",
+	"border":                "base03",
+	"success":               "base0B",
+	"warning":               "base0A",
+	"error":                 "base08",
+	"info":                  "base0D",
+	"selection_background":  "base02",
+	"filter_match":          "base0A",
+}
+
+var base24Roles = map[string]string{
+	"tags":   "base17",
+	"flags":  "base16",
+	"accent": "base17",
+}
+
+// builtinThemes holds the bundled Base16 palettes.
+var builtinThemes = map[string]map[string]string{
+	"catppuccin-mocha": {
+		"base00": "1e1e2e", "base01": "181825", "base02": "313244", "base03": "6c7086",
+		"base04": "585b70", "base05": "cdd6f4", "base06": "f5e0dc", "base07": "b4befe",
+		"base08": "f38ba8", "base09": "fab387", "base0A": "f9e2af", "base0B": "a6e3a1",
+		"base0C": "94e2d5", "base0D": "89b4fa", "base0E": "cba6f7", "base0F": "f2cdcd",
+	},
+	"catppuccin-latte": {
+		"base00": "eff1f5", "base01": "e6e9ef", "base02": "ccd0da", "base03": "9ca0b0",
+		"base04": "acb0be", "base05": "4c4f69", "base06": "dc8a78", "base07": "7287fd",
+		"base08": "d20f39", "base09": "fe640b", "base0A": "df8e1d", "base0B": "40a02b",
+		"base0C": "179299", "base0D": "1e66f5", "base0E": "8839ef", "base0F": "dd7878",
+	},
+	"dracula": {
+		"base00": "282a36", "base01": "363447", "base02": "44475a", "base03": "6272a4",
+		"base04": "9ea8c7", "base05": "f8f8f2", "base06": "f0f1f4", "base07": "ffffff",
+		"base08": "ff5555", "base09": "ffb86c", "base0A": "f1fa8c", "base0B": "50fa7b",
+		"base0C": "8be9fd", "base0D": "80bfff", "base0E": "ff79c6", "base0F": "bd93f9",
+	},
+	"gruvbox-dark": {
+		"base00": "282828", "base01": "3c3836", "base02": "504945", "base03": "665c54",
+		"base04": "bdae93", "base05": "d5c4a1", "base06": "ebdbb2", "base07": "fbf1c7",
+		"base08": "fb4934", "base09": "fe8019", "base0A": "fabd2f", "base0B": "b8bb26",
+		"base0C": "8ec07c", "base0D": "83a598", "base0E": "d3869b", "base0F": "d65d0e",
+	},
+	"nord": {
+		"base00": "2e3440", "base01": "3b4252", "base02": "434c5e", "base03": "4c566a",
+		"base04": "d8dee9", "base05": "e5e9f0", "base06": "eceff4", "base07": "8fbcbb",
+		"base08": "bf616a", "base09": "d08770", "base0A": "ebcb8b", "base0B": "a3be8c",
+		"base0C": "88c0d0", "base0D": "81a1c1", "base0E": "b48ead", "base0F": "5e81ac",
+	},
+	"tokyo-night": {
+		"base00": "1a1b26", "base01": "16161e", "base02": "2f3549", "base03": "565f89",
+		"base04": "787c99", "base05": "a9b1d6", "base06": "cbccd1", "base07": "d5d6db",
+		"base08": "f7768e", "base09": "ff9e64", "base0A": "e0af68", "base0B": "9ece6a",
+		"base0C": "7dcfff", "base0D": "7aa2f7", "base0E": "bb9af7", "base0F": "db4b4b",
+	},
+}
+
+// ThemesDir returns the directory user theme files are read from.
+func ThemesDir() string {
+	return filepath.Join(filepath.Dir(utils.ConfigPathGlobal()), "themes")
+}
+
+// Themes lists the built-in themes followed by theme files in ThemesDir.
+// A theme file with the same name as a built-in theme replaces it.
+func Themes() ([]ThemeInfo, error) {
+	byName := map[string]ThemeInfo{DefaultTheme: {Name: DefaultTheme}}
+	for name := range builtinThemes {
+		byName[name] = ThemeInfo{Name: name}
+	}
+	entries, err := os.ReadDir(ThemesDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || !containsString(Extensions(), strings.ToLower(ext)) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ext)
+		// Report the file ApplyTheme would read when several formats exist
+		path, _, err := FindConfigFile(filepath.Join(ThemesDir(), name+".toml"))
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = ThemeInfo{Name: name, Path: path}
+	}
+
+	themes := make([]ThemeInfo, 0, len(byName))
+	for _, theme := range byName {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes, nil
+}
+
+// ApplyTheme sets the color keys of cfg from the named theme. Theme files
+// in ThemesDir take precedence over built-in themes. The returned path is
+// the theme file that was read, if any.
+func ApplyTheme(cfg *domain.Config, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == DefaultTheme {
+		applyPartial(cfg, themeColors(domain.DefaultConfig()))
+		return "", nil
+	}
+	path, exists, err := FindConfigFile(filepath.Join(ThemesDir(), name+".toml"))
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		partial, err := readTheme(path)
+		if err != nil {
+			return path, err
+		}
+		applyPartial(cfg, partial)
+		return path, nil
+	}
+	if palette, ok := builtinThemes[name]; ok {
+		partial, err := base16Theme(palette)
+		if err != nil {
+			return "", err
+		}
+		applyPartial(cfg, partial)
+		return "", nil
+	}
+	return "", fmt.Errorf("theme %q not found (built-in themes or %s)", name, ThemesDir())
+}
+
+// readTheme reads a theme file. Files with Base16 or Base24 palette keys,
+// either at the top level or under "palette" as in tinted-theming schemes,
+// are mapped onto color keys; other files use the color keys directly.
+func readTheme(path string) (*partialConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]any)
+	if err := CodecFor(path).Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if palette, ok := raw["palette"].(map[string]any); ok {
+		raw = palette
+	}
+	if _, ok := raw["base00"]; ok {
+		palette := make(map[string]string, len(raw))
+		for _, key := range sortedKeys(raw) {
+			// Unquoted hex values such as 010101 or 1e1e1e decode as
+			// numbers in YAML, and the digits cannot be recovered
+			value, ok := raw[key].(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: palette value %s is not a string; quote hex colors, as in %s: \"010101\"", path, key, key)
+			}
+			palette[key] = value
+		}
+		partial, err := base16Theme(palette)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return partial, nil
+	}
+
+	theme, _, problems, err := decodePartial(CodecFor(path), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s: %s", path, strings.Join(problems, "; "))
+	}
+	return colorsOf(theme), nil
+}
+
+// base16Theme maps a Base16 or Base24 palette onto color keys.
+func base16Theme(palette map[string]string) (*partialConfig, error) {
+	_, base24 := palette["base10"]
+	values := make(map[string]string, len(base16Roles))
+	for key, slot := range base16Roles {
+		if base24 {
+			if brighter, ok := base24Roles[key]; ok {
+				slot = brighter
+			}
+		}
+		color, ok := palette[slot]
+		if !ok {
+			return nil, fmt.Errorf("palette is missing %s", slot)
+		}
+		values[key] = normalizeHex(color)
+	}
+	cfg := domain.Config{
+		Headings:             values["headings"],
+		Primary:              values["primary"],
+		Secondary:            values["secondary"],
+		Text:                 values["text"],
+		TextHighlight:        values["text_highlight"],
+		DescriptionHighlight: values["description_highlight"],
+		Tags:                 values["tags"],
+		Flags:                values["flags"],
+		Muted:                values["muted"],
+		Accent:               values["accent"],
+		Border:               values["border"],
+		Success:              values["success"],
+		Warning:              values["warning"],
+		Error:                values["error"],
+		Info:                 values["info"],
+		SelectionBackground:  values["selection_background"],
+		FilterMatch:          values["filter_match"],
+	}
+	return themeColors(cfg), nil
+}
+
+// themeColors returns a partial setting every color key from cfg.
+func themeColors(cfg domain.Config) *partialConfig {
+	return &partialConfig{
+		Headings:             &cfg.Headings,
+		Primary:              &cfg.Primary,
+		Secondary:            &cfg.Secondary,
+		Text:                 &cfg.Text,
+		TextHighlight:        &cfg.TextHighlight,
+		DescriptionHighlight: &cfg.DescriptionHighlight,
+		Tags:                 &cfg.Tags,
+		Flags:                &cfg.Flags,
+		Muted:                &cfg.Muted,
+		Accent:               &cfg.Accent,
+		Border:               &cfg.Border,
+		Success:              &cfg.Success,
+		Warning:              &cfg.Warning,
+		Error:                &cfg.Error,
+		Info:                 &cfg.Info,
+		SelectionBackground:  &cfg.SelectionBackground,
+		FilterMatch:          &cfg.FilterMatch,
+	}
+}
+
+// colorsOf keeps only the color keys of a flat theme file, so a theme can
+// never change settings such as the editor.
+func colorsOf(theme *partialConfig) *partialConfig {
+	return &partialConfig{
+		Headings:             theme.Headings,
+		Primary:              theme.Primary,
+		Secondary:            theme.Secondary,
+		Text:                 theme.Text,
+		TextHighlight:        theme.TextHighlight,
+		DescriptionHighlight: theme.DescriptionHighlight,
+		Tags:                 theme.Tags,
+		Flags:                theme.Flags,
+		Muted:                theme.Muted,
+		Accent:               theme.Accent,
+		Border:               theme.Border,
+		Success:              theme.Success,
+		Warning:              theme.Warning,
+		Error:                theme.Error,
+		Info:                 theme.Info,
+		SelectionBackground:  theme.SelectionBackground,
+		FilterMatch:          theme.FilterMatch,
+	}
+}
+
+// normalizeHex adds the leading # that Base16 schemes usually omit.
+func normalizeHex(color string) string {
+	color = strings.TrimSpace(color)
+	if color == "" || strings.HasPrefix(color, "#") {
+		return color
+	}
+	return "#" + color
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/utils"
+)
+
+func writeThemeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(ThemesDir(), name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir themes dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write theme: %v", err)
+	}
+	return path
+}
+
+func TestApplyThemeSemanticColors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg := domain.DefaultConfig()
+	if _, err := ApplyTheme(&cfg, "nord"); err != nil {
+		t.Fatalf("apply theme: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"success", cfg.Success, "#a3be8c"},
+		{"warning", cfg.Warning, "#ebcb8b"},
+		{"error", cfg.Error, "#bf616a"},
+		{"info", cfg.Info, "#81a1c1"},
+		{"selection_background", cfg.SelectionBackground, "#434c5e"},
+		{"filter_match", cfg.FilterMatch, "#ebcb8b"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		theme   string
+		primary string
+		tags    string
+	}{
+		{"built-in", "", "", "nord", "#a3be8c", "#b48ead"},
+		{"flat file", "mine.toml", "primary = \"#123456\"\n", "mine", "#123456", domain.DefaultConfig().Tags},
+		{
+			"base16 yaml",
+			"scheme.yaml",
+			"scheme: test\nbase00: \"000000\"\nbase01: \"111111\"\nbase02: \"222222\"\nbase03: \"333333\"\nbase04: \"444444\"\nbase05: \"555555\"\nbase06: \"666666\"\nbase07: \"777777\"\nbase08: \"880000\"\nbase09: \"990000\"\nbase0A: \"aa0000\"\nbase0B: \"bb0000\"\nbase0C: \"cc0000\"\nbase0D: \"dd0000\"\nbase0E: \"ee0000\"\nbase0F: \"ff0000\"\n",
+			"scheme",
+			"#bb0000",
+			"#ee0000",
+		},
+		{
+			"base24 palette table",
+			"bright.toml",
+			"system = \"base24\"\n\n[palette]\nbase00 = \"#000000\"\nbase01 = \"#111111\"\nbase02 = \"#222222\"\nbase03 = \"#333333\"\nbase04 = \"#444444\"\nbase05 = \"#555555\"\nbase06 = \"#666666\"\nbase07 = \"#777777\"\nbase08 = \"#880000\"\nbase09 = \"#990000\"\nbase0A = \"#aa0000\"\nbase0B = \"#bb0000\"\nbase0C = \"#cc0000\"\nbase0D = \"#dd0000\"\nbase0E = \"#ee0000\"\nbase0F = \"#ff0000\"\nbase10 = \"#000010\"\nbase11 = \"#000011\"\nbase12 = \"#000012\"\nbase13 = \"#000013\"\nbase14 = \"#000014\"\nbase15 = \"#000015\"\nbase16 = \"#000016\"\nbase17 = \"#000017\"\n",
+			"bright",
+			"#bb0000",
+			"#000017",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if tt.file != "" {
+				writeThemeFile(t, tt.file, tt.content)
+			}
+			cfg := domain.DefaultConfig()
+			if _, err := ApplyTheme(&cfg, tt.theme); err != nil {
+				t.Fatalf("apply theme: %v", err)
+			}
+			if cfg.Primary != tt.primary {
+				t.Errorf("primary = %q, want %q", cfg.Primary, tt.primary)
+			}
+			if cfg.Tags != tt.tags {
+				t.Errorf("tags = %q, want %q", cfg.Tags, tt.tags)
+			}
+			if cfg.Editor != domain.DefaultConfig().Editor {
+				t.Error("expected themes to leave non-color settings alone")
+			}
+		})
+	}
+
+	t.Run("unknown theme", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		cfg := domain.DefaultConfig()
+		if _, err := ApplyTheme(&cfg, "missing"); err == nil {
+			t.Error("expected error for an unknown theme")
+		}
+	})
+
+	t.Run("unquoted palette value", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		writeThemeFile(t, "scheme.yaml", "scheme: test\nbase00: 010101\nbase01: \"111111\"\n")
+		cfg := domain.DefaultConfig()
+		_, err := ApplyTheme(&cfg, "scheme")
+		if err == nil || !strings.Contains(err.Error(), "base00 is not a string") {
+			t.Errorf("expected error asking to quote base00, got %v", err)
+		}
+	})
+}
+
+func TestThemes(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := writeThemeFile(t, "nord.toml", "primary = \"#000000\"\n")
+	writeThemeFile(t, "notes.txt", "not a theme")
+
+	themes, err := Themes()
+	if err != nil {
+		t.Fatalf("themes: %v", err)
+	}
+	names := make([]string, 0, len(themes))
+	for _, theme := range themes {
+		names = append(names, theme.Name)
+		if theme.Name == "nord" && theme.Path != path {
+			t.Errorf("expected user nord theme to replace the built-in one, got %+v", theme)
+		}
+	}
+	if got := strings.Join(names, ","); !strings.Contains(got, "default") || !strings.Contains(got, "catppuccin-mocha") || strings.Contains(got, "notes") {
+		t.Errorf("unexpected theme list %s", got)
+	}
+}
+
+func TestManagerTheme(t *testing.T) {
+	root := t.TempDir()
+	cwd := filepath.Join(root, "project")
+	if err := os.MkdirAll(cwd, 0o755); err != nil {
+		t.Fatalf("mkdir cwd: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	themePath := writeThemeFile(t, "mine.toml", "primary = \"#111111\"\nmuted = \"#222222\"\neditor = \"ignored\"\n")
+
+	configPath := utils.ConfigPathGlobal()
+	if err := os.WriteFile(configPath, []byte("version = 1\ntheme = \"mine\"\nmuted = \"01\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	manager := NewManager(cwd)
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Primary != "#111111" {
+		t.Errorf("expected primary from theme, got %q", cfg.Primary)
+	}
+	if cfg.Muted != "01" {
+		t.Errorf("expected config key to override theme, got %q", cfg.Muted)
+	}
+	if cfg.Editor != domain.DefaultConfig().Editor {
+		t.Errorf("expected theme file not to set editor, got %q", cfg.Editor)
+	}
+	if !containsString(manager.Sources(), themePath) {
+		t.Errorf("expected theme file in sources, got %v", manager.Sources())
+	}
+
+	if err := os.WriteFile(configPath, []byte("version = 1\ntheme = \"missing\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("expected unknown theme to be a warning, got %v", err)
+	}
+	if warnings := strings.Join(manager.Warnings(), "\n"); !strings.Contains(warnings, `theme "missing" not found`) {
+		t.Errorf("expected unknown theme warning, got %q", warnings)
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/utils"
+)
+
+// validListSpacing lists the accepted list_spacing values.
+var validListSpacing = []string{"compact", "tight", "space"}
+
+// validBoundaries lists the accepted local_config_boundary values.
+var validBoundaries = []string{utils.BoundaryGit, utils.BoundaryHome, utils.BoundaryRoot, utils.BoundaryNone}
+
+// validKeymaps lists the accepted keymap presets.
+var validKeymaps = []string{"default", "vim", "emacs"}
+
+// validEditorLayouts lists the accepted editor_layout values.
+var validEditorLayouts = []string{"tabs", "vsplit", "split"}
+
+// knownKeys returns the set of config keys defined by partialConfig.
+func knownKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(partialConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("toml"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// validatePartial reports values that are set but not accepted. Problems
+// are returned as warnings so a single bad value never blocks loading.
+func validatePartial(partial *partialConfig) []string {
+	var problems []string
+	if partial.ListSpacing != nil && !containsString(validListSpacing, *partial.ListSpacing) {
+		problems = append(problems, fmt.Sprintf("list_spacing %q is not one of %s", *partial.ListSpacing, strings.Join(validListSpacing, ", ")))
+	}
+	if partial.LocalConfigBoundary != nil && !containsString(validBoundaries, *partial.LocalConfigBoundary) {
+		problems = append(problems, fmt.Sprintf("local_config_boundary %q is not one of %s", *partial.LocalConfigBoundary, strings.Join(validBoundaries, ", ")))
+	}
+	if partial.Keymap != nil && !containsString(validKeymaps, *partial.Keymap) {
+		problems = append(problems, fmt.Sprintf("keymap %q is not one of %s", *partial.Keymap, strings.Join(validKeymaps, ", ")))
+	}
+	if partial.EditorLayout != nil && !containsString(validEditorLayouts, *partial.EditorLayout) {
+		problems = append(problems, fmt.Sprintf("editor_layout %q is not one of %s", *partial.EditorLayout, strings.Join(validEditorLayouts, ", ")))
+	}
+	if partial.DiffTool != nil {
+		if _, err := utils.SplitWords(*partial.DiffTool); err != nil {
+			problems = append(problems, fmt.Sprintf("diff_tool %q: %v", *partial.DiffTool, err))
+		}
+	}
+	if partial.EditorLineTemplate != nil {
+		if _, err := utils.SplitWords(*partial.EditorLineTemplate); err != nil {
+			problems = append(problems, fmt.Sprintf("editor_line_template %q: %v", *partial.EditorLineTemplate, err))
+		}
+	}
+	for _, action := range sortedActions(partial.Keys) {
+		if !containsString(domain.KeyActions, action) {
+			problems = append(problems, fmt.Sprintf("keys.%s is not a known action (%s)", action, strings.Join(domain.KeyActions, ", ")))
+		}
+	}
+	for name, profile := range partial.Profiles {
+		if profile == nil {
+			continue
+		}
+		for _, problem := range validatePartial(profile) {
+			problems = append(problems, fmt.Sprintf("profiles.%s: %s", name, problem))
+		}
+	}
+	return problems
+}
+
+// ValidateFile checks a single config file without loading its includes.
+// Problems with values are returned as warnings; an error means the file
+// is missing or cannot be parsed.
+func ValidateFile(path string) ([]string, error) {
+	partial, err := readConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if partial == nil {
+		return nil, fmt.Errorf("%s does not exist", path)
+	}
+	return partial.warnings, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedActions(keys map[string][]string) []string {
+	actions := make([]string, 0, len(keys))
+	for action := range keys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "strings"
+
+// adaptiveSeparator joins the light and dark halves of an adaptive color.
+const adaptiveSeparator = "|"
+
+// AdaptiveColor encodes a color that differs between light and dark
+// terminal backgrounds as a single config value, "light|dark". Config
+// files may also write it as a { light = "..", dark = ".." } table.
+func AdaptiveColor(light, dark string) string {
+	return strings.TrimSpace(light) + adaptiveSeparator + strings.TrimSpace(dark)
+}
+
+// SplitColor returns the light and dark background values of a config
+// color. Plain colors return the same value for both, as does an adaptive
+// color with one half left empty.
+func SplitColor(value string) (light, dark string) {
+	value = strings.TrimSpace(value)
+	light, dark, ok := strings.Cut(value, adaptiveSeparator)
+	if !ok {
+		return value, value
+	}
+	light, dark = strings.TrimSpace(light), strings.TrimSpace(dark)
+	if light == "" {
+		light = dark
+	}
+	if dark == "" {
+		dark = light
+	}
+	return light, dark
+}
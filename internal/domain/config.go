@@ -5,32 +5,57 @@ import (
 	"path/filepath"
 )
 
+// ConfigVersion is the current config file schema version. Bump it when
+// options are renamed or restructured and register a migration for the
+// previous version in internal/config.
+const ConfigVersion = 1
+
 // Config describes the resolved configuration.
 type Config struct {
-	Editor               string `toml:"editor"`
-	Primary              string `toml:"primary"`
-	Secondary            string `toml:"secondary"`
-	Headings             string `toml:"headings"`
-	Text                 string `toml:"text"`
-	TextHighlight        string `toml:"text_highlight"`
-	DescriptionHighlight string `toml:"description_highlight"`
-	Tags                 string `toml:"tags"`
-	Flags                string `toml:"flags"`
-	Muted                string `toml:"muted"`
-	Accent               string `toml:"accent"`
-	Border               string `toml:"border"`
-	InteractiveDefault   bool   `toml:"interactive_default"`
-	ListSpacing          string `toml:"list_spacing"`
+	Version              int    `toml:"version" yaml:"version" json:"version"`
+	Editor               string `toml:"editor" yaml:"editor" json:"editor"`
+	EditorLineTemplate   string `toml:"editor_line_template" yaml:"editor_line_template" json:"editor_line_template"`
+	EditorLayout         string `toml:"editor_layout" yaml:"editor_layout" json:"editor_layout"`
+	EditorWait           bool   `toml:"editor_wait" yaml:"editor_wait" json:"editor_wait"`
+	DiffTool             string `toml:"diff_tool" yaml:"diff_tool" json:"diff_tool"`
+	Theme                string `toml:"theme" yaml:"theme" json:"theme"`
+	Primary              string `toml:"primary" yaml:"primary" json:"primary"`
+	Secondary            string `toml:"secondary" yaml:"secondary" json:"secondary"`
+	Headings             string `toml:"headings" yaml:"headings" json:"headings"`
+	Text                 string `toml:"text" yaml:"text" json:"text"`
+	TextHighlight        string `toml:"text_highlight" yaml:"text_highlight" json:"text_highlight"`
+	DescriptionHighlight string `toml:"description_highlight" yaml:"description_highlight" json:"description_highlight"`
+	Tags                 string `toml:"tags" yaml:"tags" json:"tags"`
+	Flags                string `toml:"flags" yaml:"flags" json:"flags"`
+	Muted                string `toml:"muted" yaml:"muted" json:"muted"`
+	Accent               string `toml:"accent" yaml:"accent" json:"accent"`
+	Border               string `toml:"border" yaml:"border" json:"border"`
+	Success              string `toml:"success" yaml:"success" json:"success"`
+	Warning              string `toml:"warning" yaml:"warning" json:"warning"`
+	Error                string `toml:"error" yaml:"error" json:"error"`
+	Info                 string `toml:"info" yaml:"info" json:"info"`
+	SelectionBackground  string `toml:"selection_background" yaml:"selection_background" json:"selection_background"`
+	FilterMatch          string `toml:"filter_match" yaml:"filter_match" json:"filter_match"`
+	InteractiveDefault   bool   `toml:"interactive_default" yaml:"interactive_default" json:"interactive_default"`
+	ListSpacing          string `toml:"list_spacing" yaml:"list_spacing" json:"list_spacing"`
+	LocalConfigBoundary  string `toml:"local_config_boundary" yaml:"local_config_boundary" json:"local_config_boundary"`
+	Keymap               string `toml:"keymap" yaml:"keymap" json:"keymap"`
+	// Keys maps browser actions to key overrides on top of Keymap.
+	Keys map[string][]string `toml:"keys" yaml:"keys" json:"keys"`
 }
 
 // DefaultConfig returns the default configuration values.
 func DefaultConfig() Config {
 	return Config{
+		Version:              ConfigVersion,
 		Editor:               "nvim",
-		Headings:             "15",
+		EditorLayout:         "tabs",
+		EditorWait:           true,
+		Theme:                "default",
+		Headings:             AdaptiveColor("0", "15"),
 		Primary:              "02",
 		Secondary:            "06",
-		Text:                 "07",
+		Text:                 AdaptiveColor("0", "07"),
 		TextHighlight:        "06",
 		DescriptionHighlight: "05",
 		Tags:                 "13",
@@ -38,8 +63,15 @@ func DefaultConfig() Config {
 		Muted:                "08",
 		Accent:               "13",
 		Border:               "08",
+		Success:              "02",
+		Warning:              "03",
+		Error:                "01",
+		Info:                 "04",
+		FilterMatch:          "06",
 		InteractiveDefault:   true,
 		ListSpacing:          "space",
+		LocalConfigBoundary:  "git",
+		Keymap:               "default",
 	}
 }
 
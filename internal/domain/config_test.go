@@ -39,8 +39,8 @@ func TestDefaultConfig(t *testing.T) {
 	})
 
 	t.Run("has expected default values", func(t *testing.T) {
-		if cfg.Headings != "15" {
-			t.Errorf("DefaultConfig().Headings = %q, want %q", cfg.Headings, "15")
+		if cfg.Headings != "0|15" {
+			t.Errorf("DefaultConfig().Headings = %q, want %q", cfg.Headings, "0|15")
 		}
 		if cfg.Primary != "02" {
 			t.Errorf("DefaultConfig().Primary = %q, want %q", cfg.Primary, "02")
@@ -48,8 +48,8 @@ func TestDefaultConfig(t *testing.T) {
 		if cfg.Secondary != "06" {
 			t.Errorf("DefaultConfig().Secondary = %q, want %q", cfg.Secondary, "06")
 		}
-		if cfg.Text != "07" {
-			t.Errorf("DefaultConfig().Text = %q, want %q", cfg.Text, "07")
+		if cfg.Text != "0|07" {
+			t.Errorf("DefaultConfig().Text = %q, want %q", cfg.Text, "0|07")
 		}
 		if cfg.TextHighlight != "06" {
 			t.Errorf("DefaultConfig().TextHighlight = %q, want %q", cfg.TextHighlight, "06")
@@ -124,3 +124,24 @@ func TestConfig_StructTags(t *testing.T) {
 		}
 	})
 }
+
+func TestSplitColor(t *testing.T) {
+	tests := []struct {
+		value string
+		light string
+		dark  string
+	}{
+		{"13", "13", "13"},
+		{" #ff8800 ", "#ff8800", "#ff8800"},
+		{"0|15", "0", "15"},
+		{AdaptiveColor(" #005f00", "10 "), "#005f00", "10"},
+		{"|07", "07", "07"},
+		{"04|", "04", "04"},
+	}
+	for _, tt := range tests {
+		light, dark := SplitColor(tt.value)
+		if light != tt.light || dark != tt.dark {
+			t.Errorf("SplitColor(%q) = %q, %q, want %q, %q", tt.value, light, dark, tt.light, tt.dark)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+// Browser actions that can be bound in the [keys] config table.
+const (
+	KeyView    = "view"
+	KeyAdd     = "add"
+	KeyDelete  = "delete"
+	KeyRename  = "rename"
+	KeyOpen    = "open"
+	KeyMark    = "mark"
+	KeyDiff    = "diff"
+	KeyHistory = "history"
+	KeyQuit    = "quit"
+)
+
+// KeyActions lists the bindable actions in help order.
+var KeyActions = []string{KeyView, KeyAdd, KeyDelete, KeyRename, KeyOpen, KeyMark, KeyDiff, KeyHistory, KeyQuit}
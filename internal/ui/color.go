@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+// Color modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ColorModes lists the accepted color modes.
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// SetupColor sets the color profile used by every renderer from mode and
+// the NO_COLOR, CLICOLOR and CLICOLOR_FORCE environment variables, then
+// detects the terminal background so adaptive colors pick the right
+// variant. It must run before any Bubble Tea program starts, as the
+// background query reads from the terminal.
+func SetupColor(mode string) error {
+	profile, err := resolveColorProfile(mode, os.Getenv, func(forceTTY bool) termenv.Profile {
+		return termenv.NewOutput(os.Stdout, termenv.WithTTY(forceTTY)).ColorProfile()
+	})
+	if err != nil {
+		return err
+	}
+	lipgloss.SetColorProfile(profile)
+	if profile != termenv.Ascii && term.IsTerminal(int(os.Stdout.Fd())) {
+		lipgloss.SetHasDarkBackground(termenv.NewOutput(os.Stdout).HasDarkBackground())
+	}
+	return nil
+}
+
+// resolveColorProfile picks the color profile for mode. An explicit mode
+// wins over the environment; in auto mode NO_COLOR wins over
+// CLICOLOR_FORCE. detect reports the terminal's profile, assuming a
+// terminal when forceTTY is set.
+func resolveColorProfile(mode string, getenv func(string) string, detect func(forceTTY bool) termenv.Profile) (termenv.Profile, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case ColorNever:
+		return termenv.Ascii, nil
+	case ColorAlways:
+		return forcedProfile(detect(true)), nil
+	case ColorAuto, "":
+	default:
+		return termenv.Ascii, fmt.Errorf("invalid color mode %q (expected %s)", mode, strings.Join(ColorModes, ", "))
+	}
+
+	if getenv("NO_COLOR") != "" {
+		return termenv.Ascii, nil
+	}
+	if forced := getenv("CLICOLOR_FORCE"); forced != "" && forced != "0" {
+		return forcedProfile(detect(true)), nil
+	}
+	if getenv("CLICOLOR") == "0" {
+		return termenv.Ascii, nil
+	}
+	return detect(false), nil
+}
+
+// forcedProfile falls back to basic ANSI colors when colors are forced on
+// a terminal that reports none, such as TERM=dumb.
+func forcedProfile(profile termenv.Profile) termenv.Profile {
+	if profile == termenv.Ascii {
+		return termenv.ANSI
+	}
+	return profile
+}
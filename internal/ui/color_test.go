@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestResolveColorProfile(t *testing.T) {
+	// detect mimics a pipe: no colors unless a terminal is assumed
+	detect := func(forceTTY bool) termenv.Profile {
+		if forceTTY {
+			return termenv.TrueColor
+		}
+		return termenv.Ascii
+	}
+	tests := []struct {
+		name string
+		mode string
+		env  map[string]string
+		want termenv.Profile
+	}{
+		{"auto detects", ColorAuto, nil, termenv.Ascii},
+		{"empty mode is auto", "", nil, termenv.Ascii},
+		{"always forces colors", ColorAlways, nil, termenv.TrueColor},
+		{"never disables colors", ColorNever, map[string]string{"CLICOLOR_FORCE": "1"}, termenv.Ascii},
+		{"NO_COLOR", ColorAuto, map[string]string{"NO_COLOR": "1"}, termenv.Ascii},
+		{"NO_COLOR wins over CLICOLOR_FORCE", ColorAuto, map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, termenv.Ascii},
+		{"CLICOLOR_FORCE", ColorAuto, map[string]string{"CLICOLOR_FORCE": "1"}, termenv.TrueColor},
+		{"CLICOLOR_FORCE=0 is ignored", ColorAuto, map[string]string{"CLICOLOR_FORCE": "0"}, termenv.Ascii},
+		{"always overrides NO_COLOR", ColorAlways, map[string]string{"NO_COLOR": "1"}, termenv.TrueColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			got, err := resolveColorProfile(tt.mode, getenv, detect)
+			if err != nil {
+				t.Fatalf("resolveColorProfile: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveColorProfile(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("forced colors on a dumb terminal", func(t *testing.T) {
+		got, err := resolveColorProfile(ColorAlways, func(string) string { return "" }, func(bool) termenv.Profile { return termenv.Ascii })
+		if err != nil || got != termenv.ANSI {
+			t.Errorf("expected ANSI fallback, got %v (%v)", got, err)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		if _, err := resolveColorProfile("sometimes", func(string) string { return "" }, detect); err == nil {
+			t.Error("expected an error for an invalid mode")
+		}
+	})
+}
+
+func TestThemeFromConfigAdaptive(t *testing.T) {
+	cfg := domain.DefaultConfig()
+	cfg.Primary = domain.AdaptiveColor("#005f00", "10")
+	cfg.Muted = "|07"
+	theme := ThemeFromConfig(cfg)
+
+	if got, want := theme.Primary, (lipgloss.AdaptiveColor{Light: "#005f00", Dark: "10"}); got != want {
+		t.Errorf("Primary = %#v, want %#v", got, want)
+	}
+	if got, want := theme.Muted, lipgloss.Color("07"); got != want {
+		t.Errorf("Muted = %#v, want %#v", got, want)
+	}
+	if got, want := theme.Secondary, lipgloss.Color("06"); got != want {
+		t.Errorf("Secondary = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/go-cli-template/internal/domain"
+	"github.com/go-cli-template/internal/utils"
+)
+
+// colorField pairs a config color key with the field it edits.
+type colorField struct {
+	key      string
+	title    string
+	value    *string
+	optional bool
+}
+
+// NewConfigForm builds a huh form bound directly to cfg. Color inputs show a
+// swatch and a sample list rendered with the theme built from the values
+// being edited, so changes preview live. confirmSave is set by the final
+// confirmation step.
+func NewConfigForm(cfg *domain.Config, confirmSave *bool, theme Theme) *huh.Form {
+	general := huh.NewGroup(
+		huh.NewInput().
+			Key("editor").
+			Title("Editor").
+			Description("Command used to open files; empty falls back to $VISUAL or $EDITOR.").
+			Value(&cfg.Editor),
+		huh.NewInput().
+			Key("editor_line_template").
+			Title("Editor line template").
+			Description("Opens files at a position, e.g. hx {file}:{line}:{col}; empty uses the built-in syntax.").
+			Validate(validateWords).
+			Value(&cfg.EditorLineTemplate),
+		huh.NewSelect[string]().
+			Key("editor_layout").
+			Title("Editor layout").
+			Description("How vim arranges several files opened together.").
+			Options(
+				huh.NewOption("tabs", "tabs"),
+				huh.NewOption("vsplit (side by side)", "vsplit"),
+				huh.NewOption("split (stacked)", "split"),
+			).
+			Value(&cfg.EditorLayout),
+		huh.NewConfirm().
+			Key("editor_wait").
+			Title("Wait for GUI editors").
+			Description("Start editors such as code or subl with their wait flag.").
+			Value(&cfg.EditorWait),
+		huh.NewInput().
+			Key("diff_tool").
+			Title("Diff tool").
+			Description("Compares marked files, e.g. meld; empty uses the editor's diff mode.").
+			Validate(validateWords).
+			Value(&cfg.DiffTool),
+		huh.NewConfirm().
+			Key("interactive_default").
+			Title("Interactive by default").
+			Description("Start the interactive browser when no arguments are given.").
+			Value(&cfg.InteractiveDefault),
+		huh.NewSelect[string]().
+			Key("list_spacing").
+			Title("List spacing").
+			Options(
+				huh.NewOption("compact (title only)", "compact"),
+				huh.NewOption("tight (title + description, no margin)", "tight"),
+				huh.NewOption("space (title + description, with spacing)", "space"),
+			).
+			Value(&cfg.ListSpacing),
+	).Title("General")
+
+	colors := []colorField{
+		{"headings", "Headings", &cfg.Headings, false},
+		{"primary", "Primary", &cfg.Primary, false},
+		{"secondary", "Secondary", &cfg.Secondary, false},
+		{"text", "Text", &cfg.Text, false},
+		{"text_highlight", "Text highlight", &cfg.TextHighlight, false},
+		{"description_highlight", "Description highlight", &cfg.DescriptionHighlight, false},
+		{"tags", "Tags", &cfg.Tags, false},
+		{"flags", "Flags", &cfg.Flags, false},
+		{"muted", "Muted", &cfg.Muted, false},
+		{"accent", "Accent", &cfg.Accent, false},
+		{"border", "Border", &cfg.Border, false},
+		{"success", "Success", &cfg.Success, false},
+		{"warning", "Warning", &cfg.Warning, false},
+		{"error", "Error", &cfg.Error, false},
+		{"info", "Info", &cfg.Info, false},
+		{"selection_background", "Selection background", &cfg.SelectionBackground, true},
+		{"filter_match", "Filter match", &cfg.FilterMatch, false},
+	}
+	colorInputs := make([]huh.Field, 0, len(colors))
+	for _, field := range colors {
+		field := field
+		colorInputs = append(colorInputs, huh.NewInput().
+			Key(field.key).
+			Title(field.title).
+			Validate(func(value string) error {
+				if field.optional {
+					return nil
+				}
+				return validateColor(value)
+			}).
+			DescriptionFunc(func() string {
+				return ColorSwatch(*field.value) + "\n" + ThemePreview(ThemeFromConfig(*cfg))
+			}, cfg).
+			Value(field.value))
+	}
+
+	save := huh.NewGroup(
+		huh.NewConfirm().
+			Key("save").
+			Title("Save changes?").
+			Affirmative("Save").
+			Negative("Discard").
+			Value(confirmSave),
+	)
+
+	return huh.NewForm(general, huh.NewGroup(colorInputs...).Title("Colors"), save).
+		WithTheme(formHuhTheme(theme))
+}
+
+// ColorSwatch renders a block of the given color followed by its value.
+func ColorSwatch(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "(unset)"
+	}
+	color := resolveColor(value, "")
+	block := lipgloss.NewStyle().Background(color).Render("      ")
+	return block + " " + lipgloss.NewStyle().Foreground(color).Render(value)
+}
+
+// ThemePreview renders sample list items styled with theme.
+func ThemePreview(theme Theme) string {
+	heading := lipgloss.NewStyle().Foreground(theme.Headings).Bold(true).Render("Preview")
+	title := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render("Directory: ~/projects")
+	selectedTitle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary).
+		Foreground(theme.TextHighlight).
+		Bold(true).
+		Padding(0, 0, 0, 1).
+		Render("README.md")
+	selectedDesc := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Primary).
+		Foreground(theme.DescriptionHighlight).
+		Padding(0, 0, 0, 1).
+		Render("2 hours ago")
+	normalTitle := lipgloss.NewStyle().Foreground(theme.Text).Padding(0, 0, 0, 2).Render("main.go")
+	normalDesc := lipgloss.NewStyle().Foreground(theme.Muted).Padding(0, 0, 0, 2).Render("3 days ago")
+	meta := lipgloss.NewStyle().Foreground(theme.Tags).Render("#tag") + " " +
+		lipgloss.NewStyle().Foreground(theme.Flags).Render("--flag") + " " +
+		lipgloss.NewStyle().Foreground(theme.Secondary).Render("secondary")
+
+	content := strings.Join([]string{
+		heading,
+		title,
+		"",
+		selectedTitle,
+		selectedDesc,
+		"",
+		normalTitle,
+		normalDesc,
+		"",
+		meta,
+	}, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(0, 1).
+		Render(content)
+}
+
+func validateColor(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("color is required")
+	}
+	return nil
+}
+
+func validateWords(value string) error {
+	_, err := utils.SplitWords(value)
+	return err
+}
+
+func formHuhTheme(theme Theme) *huh.Theme {
+	huhTheme := huh.ThemeBase()
+	huhTheme.Focused.Base = huhTheme.Focused.Base.BorderForeground(theme.Border)
+	huhTheme.Focused.Title = huhTheme.Focused.Title.Foreground(theme.Primary).Bold(true)
+	huhTheme.Focused.Description = huhTheme.Focused.Description.Foreground(theme.Muted)
+	huhTheme.Focused.SelectSelector = huhTheme.Focused.SelectSelector.Foreground(theme.Secondary)
+	huhTheme.Focused.SelectedOption = huhTheme.Focused.SelectedOption.Foreground(theme.TextHighlight)
+	huhTheme.Focused.Option = huhTheme.Focused.Option.Foreground(theme.Text)
+	huhTheme.Focused.FocusedButton = huhTheme.Focused.FocusedButton.Foreground(theme.Text).Background(theme.SelectionBackground).Bold(true)
+	huhTheme.Focused.BlurredButton = huhTheme.Focused.BlurredButton.Foreground(theme.Text)
+	huhTheme.Focused.TextInput.Prompt = huhTheme.Focused.TextInput.Prompt.Foreground(theme.Secondary)
+	huhTheme.Focused.TextInput.Cursor = huhTheme.Focused.TextInput.Cursor.Foreground(theme.Secondary)
+	huhTheme.Focused.TextInput.Text = huhTheme.Focused.TextInput.Text.Foreground(theme.Text)
+	huhTheme.Focused.ErrorIndicator = huhTheme.Focused.ErrorIndicator.Foreground(theme.Error)
+	huhTheme.Focused.ErrorMessage = huhTheme.Focused.ErrorMessage.Foreground(theme.Error)
+	huhTheme.Blurred = huhTheme.Focused
+	huhTheme.Blurred.Base = huhTheme.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	huhTheme.Group.Title = huhTheme.Focused.Title
+	huhTheme.Group.Description = huhTheme.Focused.Description
+	return huhTheme
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestColorSwatch(t *testing.T) {
+	if got := ColorSwatch(""); got != "(unset)" {
+		t.Errorf("ColorSwatch(\"\") = %q, want %q", got, "(unset)")
+	}
+	if got := ColorSwatch("#ff8800"); !strings.Contains(got, "#ff8800") {
+		t.Errorf("ColorSwatch() = %q, should contain the color value", got)
+	}
+}
+
+func TestThemePreview(t *testing.T) {
+	preview := ThemePreview(ThemeFromConfig(domain.DefaultConfig()))
+	for _, want := range []string{"Preview", "README.md", "main.go"} {
+		if !strings.Contains(preview, want) {
+			t.Errorf("ThemePreview() should contain %q", want)
+		}
+	}
+}
+
+func TestNewConfigForm(t *testing.T) {
+	cfg := domain.DefaultConfig()
+	save := true
+	form := NewConfigForm(&cfg, &save, ThemeFromConfig(cfg))
+	if form == nil {
+		t.Fatal("expected form to be created")
+	}
+	if view := form.View(); view == "" {
+		t.Error("expected form to render")
+	}
+}
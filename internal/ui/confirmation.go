@@ -213,7 +213,7 @@ func confirmationHuhTheme(theme Theme) *huh.Theme {
 		Padding(0, 2).
 		MarginRight(1).
 		Foreground(theme.Text).
-		Background(theme.Border).
+		Background(theme.SelectionBackground).
 		Bold(true)
 	huhTheme.Focused.BlurredButton = lipgloss.NewStyle().
 		Padding(0, 2).
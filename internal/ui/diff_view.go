@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DiffView is a scrollable view of a unified diff, used when the editor
+// has no diff mode of its own.
+//
+// Example usage:
+//
+//	diff := utils.UnifiedDiff("a.txt", "b.txt", before, after)
+//	view := ui.NewDiffView("a.txt ↔ b.txt", diff, width, height, theme)
+//
+//	// In Update, forward keys and size changes:
+//	view, cmd = view.Update(msg)
+type DiffView struct {
+	title    string
+	theme    Theme
+	viewport viewport.Model
+}
+
+// NewDiffView returns a diff view of diff in width by height cells,
+// including the title and scroll position lines.
+func NewDiffView(title, diff string, width, height int, theme Theme) DiffView {
+	v := DiffView{
+		title:    title,
+		theme:    theme,
+		viewport: viewport.New(width, max(height-2, 1)),
+	}
+	v.viewport.SetContent(RenderDiff(diff, theme))
+	return v
+}
+
+// SetSize resizes the view.
+func (v *DiffView) SetSize(width, height int) {
+	v.viewport.Width = width
+	v.viewport.Height = max(height-2, 1)
+}
+
+// Update scrolls the diff with the viewport's keys and the mouse wheel.
+func (v DiffView) Update(msg tea.Msg) (DiffView, tea.Cmd) {
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// View renders the title, the visible part of the diff and the scroll
+// position.
+func (v DiffView) View() string {
+	title := lipgloss.NewStyle().Foreground(v.theme.Headings).Bold(true).Render(v.title)
+	position := lipgloss.NewStyle().Foreground(v.theme.Muted).
+		Render(fmt.Sprintf("%3.f%%", v.viewport.ScrollPercent()*100))
+	return title + "\n" + v.viewport.View() + "\n" + position
+}
+
+// RenderDiff colors a unified diff: file headers as headings, hunk headers
+// as info, and removed and added lines as errors and successes.
+func RenderDiff(diff string, theme Theme) string {
+	header := lipgloss.NewStyle().Foreground(theme.Headings).Bold(true)
+	hunk := lipgloss.NewStyle().Foreground(theme.Info)
+	removed := lipgloss.NewStyle().Foreground(theme.Error)
+	added := lipgloss.NewStyle().Foreground(theme.Success)
+	context := lipgloss.NewStyle().Foreground(theme.Text)
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			lines[i] = header.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunk.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removed.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = added.Render(line)
+		default:
+			lines[i] = context.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
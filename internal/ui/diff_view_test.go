@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestDiffView(t *testing.T) {
+	theme := ThemeFromConfig(domain.DefaultConfig())
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, "+line")
+	}
+	diff := "--- a.txt\n+++ b.txt\n@@ -1 +1,30 @@\n-old\n" + strings.Join(lines, "\n") + "\n"
+
+	view := NewDiffView("Diff: a.txt ↔ b.txt", diff, 40, 10, theme)
+	out := view.View()
+	for _, want := range []string{"Diff: a.txt ↔ b.txt", "--- a.txt", "@@ -1 +1,30 @@", "-old", "0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() missing %q:\n%s", want, out)
+		}
+	}
+
+	view, _ = view.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if strings.Contains(view.View(), "--- a.txt") {
+		t.Error("expected page down to scroll past the header")
+	}
+}
+
+func TestRenderDiffKeepsLines(t *testing.T) {
+	theme := ThemeFromConfig(domain.DefaultConfig())
+	diff := "--- a\n+++ b\n@@ -1 +1 @@\n-x\n+y\n"
+	if got := strings.Count(RenderDiff(diff, theme), "\n"); got != 4 {
+		t.Errorf("expected 5 rendered lines, got %d newlines", got)
+	}
+}
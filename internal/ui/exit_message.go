@@ -10,7 +10,7 @@ func ClipboardConfirm(theme Theme) string {
 // ExitMessage renders a standard framed exit message.
 func ExitMessage(theme Theme, message string, mutedText bool) string {
 	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(theme.Text)).
+		Foreground(theme.Text).
 		Margin(0, 2, 0, 2)
 
 	if mutedText {
@@ -18,8 +18,8 @@ func ExitMessage(theme Theme, message string, mutedText bool) string {
 	}
 
 	return style.Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(theme.Muted)).
-		Foreground(lipgloss.Color(theme.Secondary)).
+		BorderForeground(theme.Muted).
+		Foreground(theme.Secondary).
 		Bold(true).
 		Margin(1, 1).
 		Padding(1, 2).Render(message)
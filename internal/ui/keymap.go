@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+// Browser actions that can be bound in the [keys] config table. The names
+// are defined in domain so config validation accepts the same set.
+const (
+	KeyView    = domain.KeyView
+	KeyAdd     = domain.KeyAdd
+	KeyDelete  = domain.KeyDelete
+	KeyRename  = domain.KeyRename
+	KeyOpen    = domain.KeyOpen
+	KeyMark    = domain.KeyMark
+	KeyDiff    = domain.KeyDiff
+	KeyHistory = domain.KeyHistory
+	KeyQuit    = domain.KeyQuit
+)
+
+// KeyActions lists the bindable actions in help order.
+var KeyActions = domain.KeyActions
+
+var keyDescriptions = map[string]string{
+	KeyView:    "view file/directory",
+	KeyAdd:     "add new file",
+	KeyDelete:  "delete file",
+	KeyRename:  "rename file",
+	KeyOpen:    "open in editor",
+	KeyMark:    "mark file",
+	KeyDiff:    "diff marked files",
+	KeyHistory: "message history",
+	KeyQuit:    "quit",
+}
+
+// keyPreset is a named set of action bindings plus adjustments to the
+// list's navigation keys.
+type keyPreset struct {
+	actions map[string][]string
+	list    func(keys *list.KeyMap)
+}
+
+var keyPresets = map[string]keyPreset{
+	"default": {
+		actions: map[string][]string{
+			KeyView:    {"enter"},
+			KeyAdd:     {"a"},
+			KeyDelete:  {"d"},
+			KeyRename:  {"r"},
+			KeyOpen:    {"o"},
+			KeyMark:    {"space"},
+			KeyDiff:    {"="},
+			KeyHistory: {"M"},
+			KeyQuit:    {"q", "esc"},
+		},
+	},
+	"vim": {
+		actions: map[string][]string{
+			KeyView:    {"enter", "l"},
+			KeyAdd:     {"a"},
+			KeyDelete:  {"d", "x"},
+			KeyRename:  {"r"},
+			KeyOpen:    {"e"},
+			KeyMark:    {"space"},
+			KeyDiff:    {"="},
+			KeyHistory: {"M"},
+			KeyQuit:    {"q", "esc"},
+		},
+		list: func(keys *list.KeyMap) {
+			keys.PrevPage.SetKeys("ctrl+b", "ctrl+u", "pgup")
+			keys.PrevPage.SetHelp("ctrl+b/ctrl+u", "prev page")
+			keys.NextPage.SetKeys("ctrl+f", "ctrl+d", "pgdown")
+			keys.NextPage.SetHelp("ctrl+f/ctrl+d", "next page")
+		},
+	},
+	"emacs": {
+		actions: map[string][]string{
+			KeyView:    {"enter"},
+			KeyAdd:     {"alt+a"},
+			KeyDelete:  {"ctrl+d"},
+			KeyRename:  {"alt+r"},
+			KeyOpen:    {"ctrl+o"},
+			KeyMark:    {"ctrl+t"},
+			KeyDiff:    {"="},
+			KeyHistory: {"alt+m"},
+			KeyQuit:    {"ctrl+g", "esc"},
+		},
+		list: func(keys *list.KeyMap) {
+			keys.CursorUp.SetKeys("ctrl+p", "up")
+			keys.CursorUp.SetHelp("ctrl+p", "up")
+			keys.CursorDown.SetKeys("ctrl+n", "down")
+			keys.CursorDown.SetHelp("ctrl+n", "down")
+			keys.PrevPage.SetKeys("alt+v", "pgup")
+			keys.PrevPage.SetHelp("alt+v", "prev page")
+			keys.NextPage.SetKeys("ctrl+v", "pgdown")
+			keys.NextPage.SetHelp("ctrl+v", "next page")
+			keys.GoToStart.SetKeys("alt+<", "home")
+			keys.GoToStart.SetHelp("alt+<", "go to start")
+			keys.GoToEnd.SetKeys("alt+>", "end")
+			keys.GoToEnd.SetHelp("alt+>", "go to end")
+			keys.Filter.SetKeys("ctrl+s", "/")
+			keys.Filter.SetHelp("ctrl+s", "filter")
+		},
+	},
+}
+
+// KeyMap binds browser actions to keys. Build it with NewKeyMap so it is
+// checked against the list's own bindings.
+type KeyMap struct {
+	bindings map[string]key.Binding
+}
+
+// NewKeyMap builds the action bindings for preset with overrides from the
+// [keys] config table applied on top; an empty override list unbinds the
+// action. listKeys is adjusted in place: the preset's navigation keys are
+// applied, and keys claimed by an action are removed from paging and
+// jump bindings. Conflicts that cannot be resolved that way, such as an
+// action bound to cursor movement, filtering or help, or two actions
+// sharing a key, are returned as warnings and the conflicting key is
+// dropped from the action.
+func NewKeyMap(preset string, overrides map[string][]string, listKeys *list.KeyMap) (KeyMap, []string) {
+	selected, ok := keyPresets[preset]
+	if !ok {
+		selected = keyPresets["default"]
+	}
+	if listKeys != nil && selected.list != nil {
+		selected.list(listKeys)
+	}
+
+	var warnings []string
+	claimed := make(map[string]string)
+	bindings := make(map[string]key.Binding, len(KeyActions))
+	for _, action := range KeyActions {
+		keys := selected.actions[action]
+		if override, ok := overrides[action]; ok {
+			keys = override
+		}
+
+		var kept []string
+		for _, k := range keys {
+			k = keyName(k)
+			if owner, ok := claimed[k]; ok {
+				warnings = append(warnings, fmt.Sprintf("keys.%s: %q is already bound to %s", action, keyHelp(k), owner))
+				continue
+			}
+			if listKeys != nil {
+				if reserved := reservedListBinding(listKeys, k); reserved != "" {
+					warnings = append(warnings, fmt.Sprintf("keys.%s: %q is reserved for %s", action, keyHelp(k), reserved))
+					continue
+				}
+			}
+			claimed[k] = action
+			kept = append(kept, k)
+		}
+
+		help := make([]string, len(kept))
+		for i, k := range kept {
+			help[i] = keyHelp(k)
+		}
+		binding := key.NewBinding(
+			key.WithKeys(kept...),
+			key.WithHelp(strings.Join(help, "/"), keyDescriptions[action]),
+		)
+		if len(kept) == 0 {
+			binding.SetEnabled(false)
+		}
+		bindings[action] = binding
+	}
+
+	if listKeys != nil {
+		for _, binding := range []*key.Binding{&listKeys.PrevPage, &listKeys.NextPage, &listKeys.GoToStart, &listKeys.GoToEnd, &listKeys.ClearFilter} {
+			unbindClaimed(binding, claimed)
+		}
+		listKeys.Quit = bindings[KeyQuit]
+	}
+	return KeyMap{bindings: bindings}, warnings
+}
+
+// Binding returns the binding for action.
+func (k KeyMap) Binding(action string) key.Binding {
+	return k.bindings[action]
+}
+
+// Matches reports whether msg triggers action.
+func (k KeyMap) Matches(msg tea.KeyMsg, action string) bool {
+	binding, ok := k.bindings[action]
+	return ok && key.Matches(msg, binding)
+}
+
+// HelpKeys returns the enabled action bindings in help order. Quit is left
+// out because the list already shows it.
+func (k KeyMap) HelpKeys() []key.Binding {
+	keys := make([]key.Binding, 0, len(KeyActions))
+	for _, action := range KeyActions {
+		if action == KeyQuit {
+			continue
+		}
+		if binding := k.bindings[action]; binding.Enabled() {
+			keys = append(keys, binding)
+		}
+	}
+	return keys
+}
+
+// keyName maps the "space" alias used in config to the key bubbletea
+// reports for the space bar.
+func keyName(k string) string {
+	if k == "space" {
+		return " "
+	}
+	return k
+}
+
+// keyHelp is the inverse of keyName, for help text.
+func keyHelp(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// reservedListBinding names the list binding that owns k when that binding
+// cannot give the key up without breaking navigation.
+func reservedListBinding(listKeys *list.KeyMap, k string) string {
+	reserved := []struct {
+		name    string
+		binding key.Binding
+	}{
+		{"cursor up", listKeys.CursorUp},
+		{"cursor down", listKeys.CursorDown},
+		{"filter", listKeys.Filter},
+		{"help", listKeys.ShowFullHelp},
+		{"force quit", listKeys.ForceQuit},
+	}
+	for _, r := range reserved {
+		for _, bound := range r.binding.Keys() {
+			if bound == k {
+				return r.name
+			}
+		}
+	}
+	return ""
+}
+
+// unbindClaimed removes keys claimed by actions from binding, disabling it
+// when no keys remain.
+func unbindClaimed(binding *key.Binding, claimed map[string]string) {
+	var kept []string
+	for _, k := range binding.Keys() {
+		if _, ok := claimed[k]; !ok {
+			kept = append(kept, k)
+		}
+	}
+	if len(kept) == len(binding.Keys()) {
+		return
+	}
+	binding.SetKeys(kept...)
+	if len(kept) == 0 {
+		binding.SetEnabled(false)
+	}
+}
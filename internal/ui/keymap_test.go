@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "delete":
+		return tea.KeyMsg{Type: tea.KeyDelete}
+	case "ctrl+d":
+		return tea.KeyMsg{Type: tea.KeyCtrlD}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestKeyPresetsBindEveryAction(t *testing.T) {
+	for name := range keyPresets {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap(name, nil, &listKeys)
+		if len(warnings) != 0 {
+			t.Errorf("%s: expected no warnings, got %v", name, warnings)
+		}
+		for _, action := range KeyActions {
+			if !keys.Binding(action).Enabled() {
+				t.Errorf("%s: %s is not bound", name, action)
+			}
+		}
+	}
+}
+
+func TestNewKeyMap(t *testing.T) {
+	t.Run("default preset takes d from the list's next page", func(t *testing.T) {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap("default", nil, &listKeys)
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+		if !keys.Matches(keyMsg("d"), KeyDelete) {
+			t.Error("expected d to delete")
+		}
+		if key.Matches(keyMsg("d"), listKeys.NextPage) {
+			t.Error("expected d to be removed from next page")
+		}
+	})
+
+	t.Run("space alias binds the space bar", func(t *testing.T) {
+		keys, warnings := NewKeyMap("default", nil, nil)
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+		if !keys.Matches(keyMsg(" "), KeyMark) {
+			t.Error("expected space to mark")
+		}
+		if help := keys.Binding(KeyMark).Help().Key; help != "space" {
+			t.Errorf("help key = %q, want space", help)
+		}
+	})
+
+	t.Run("overrides replace preset keys", func(t *testing.T) {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap("default", map[string][]string{KeyDelete: {"x", "delete"}}, &listKeys)
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+		if keys.Matches(keyMsg("d"), KeyDelete) {
+			t.Error("expected d to no longer delete")
+		}
+		if !keys.Matches(keyMsg("x"), KeyDelete) || !keys.Matches(keyMsg("delete"), KeyDelete) {
+			t.Error("expected override keys to delete")
+		}
+	})
+
+	t.Run("empty override unbinds the action", func(t *testing.T) {
+		keys, _ := NewKeyMap("default", map[string][]string{KeyAdd: {}}, nil)
+		for _, binding := range keys.HelpKeys() {
+			if binding.Help().Desc == keyDescriptions[KeyAdd] {
+				t.Error("expected unbound action to be hidden from help")
+			}
+		}
+	})
+
+	t.Run("reports conflicts with reserved list keys", func(t *testing.T) {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap("default", map[string][]string{KeyOpen: {"j", "o"}}, &listKeys)
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "cursor down") {
+			t.Errorf("expected cursor down conflict, got %v", warnings)
+		}
+		if keys.Matches(keyMsg("j"), KeyOpen) || !keys.Matches(keyMsg("o"), KeyOpen) {
+			t.Error("expected conflicting key to be dropped and the rest kept")
+		}
+	})
+
+	t.Run("reports keys shared by two actions", func(t *testing.T) {
+		keys, warnings := NewKeyMap("default", map[string][]string{KeyOpen: {"d"}}, nil)
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "already bound to delete") {
+			t.Errorf("expected duplicate binding warning, got %v", warnings)
+		}
+		if keys.Binding(KeyOpen).Enabled() {
+			t.Error("expected open to be disabled once its only key is dropped")
+		}
+	})
+
+	t.Run("emacs preset rebinds navigation", func(t *testing.T) {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap("emacs", nil, &listKeys)
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+		if !keys.Matches(keyMsg("ctrl+d"), KeyDelete) {
+			t.Error("expected ctrl+d to delete")
+		}
+		if key.Matches(keyMsg("j"), listKeys.CursorDown) {
+			t.Error("expected j to no longer move down")
+		}
+	})
+
+	t.Run("vim preset has no conflicts", func(t *testing.T) {
+		listKeys := list.DefaultKeyMap()
+		keys, warnings := NewKeyMap("vim", nil, &listKeys)
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+		if !keys.Matches(keyMsg("l"), KeyView) {
+			t.Error("expected l to view")
+		}
+	})
+}
@@ -26,6 +26,7 @@ type ListDelegateOptions struct {
 	Spacing             string // "compact", "tight", or "space" (default)
 	ShowMetadata        bool   // Enable metadata row support
 	MetadataIndent      int    // Indentation for metadata row (default: 1)
+	Filtered            bool   // Set while a filter is active, see FilterMatch
 }
 
 // NewListModel creates a list with shared styles applied.
@@ -44,7 +45,7 @@ func ApplyListStyles(model *list.Model, theme Theme) {
 	model.Styles.NoItems = model.Styles.NoItems.Foreground(theme.Muted)
 	model.Styles.StatusBar = model.Styles.StatusBar.Foreground(theme.Muted)
 	model.Styles.StatusEmpty = model.Styles.StatusEmpty.Foreground(theme.Muted)
-	model.Styles.StatusBarActiveFilter = model.Styles.StatusBarActiveFilter.Foreground(theme.Secondary)
+	model.Styles.StatusBarActiveFilter = model.Styles.StatusBarActiveFilter.Foreground(theme.Accent)
 	model.Styles.StatusBarFilterCount = model.Styles.StatusBarFilterCount.Foreground(theme.Muted)
 	model.Styles.HelpStyle = model.Styles.HelpStyle.Foreground(theme.Muted)
 	model.Styles.PaginationStyle = model.Styles.PaginationStyle.Foreground(theme.Muted)
@@ -72,7 +73,7 @@ func ApplyListFilterStyles(model *list.Model, theme Theme) {
 	model.FilterInput.PromptStyle = model.FilterInput.PromptStyle.Foreground(theme.Secondary)
 	model.FilterInput.Cursor.Style = model.FilterInput.Cursor.Style.Foreground(theme.Secondary)
 	model.FilterInput.TextStyle = model.FilterInput.TextStyle.Foreground(theme.Text)
-	model.Styles.DefaultFilterCharacterMatch = model.Styles.DefaultFilterCharacterMatch.Foreground(theme.Secondary)
+	model.Styles.DefaultFilterCharacterMatch = model.Styles.DefaultFilterCharacterMatch.Foreground(theme.FilterMatch).Underline(true)
 }
 
 // NewListDelegate provides shared list focus styles.
@@ -88,8 +89,15 @@ func NewListDelegate(theme Theme, opts ListDelegateOptions) list.ItemDelegate {
 
 func newDefaultDelegate(theme Theme, opts ListDelegateOptions) list.DefaultDelegate {
 	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(theme.TextHighlight).BorderForeground(theme.Primary).Bold(true)
-	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.Foreground(theme.DescriptionHighlight).BorderForeground(theme.Primary)
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(theme.TextHighlight).Background(theme.SelectionBackground).BorderForeground(theme.Primary).Bold(true)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.Foreground(theme.DescriptionHighlight).Background(theme.SelectionBackground).BorderForeground(theme.Primary)
+	// Matches are styled with Inherit, which keeps the title's own color, so
+	// while filtering the normal title uses the terminal foreground for the
+	// match color to show. Every item listed then has matches.
+	if opts.Filtered {
+		delegate.Styles.NormalTitle = delegate.Styles.NormalTitle.UnsetForeground()
+	}
+	delegate.Styles.FilterMatch = delegate.Styles.FilterMatch.Foreground(theme.FilterMatch).Underline(true)
 	
 	// Apply spacing configuration
 	spacing := opts.Spacing
@@ -422,3 +422,15 @@ func TestMetadataDelegate(t *testing.T) {
 		}
 	})
 }
+
+func TestNewListDelegateFiltered(t *testing.T) {
+	theme := Theme{Primary: lipgloss.Color("2"), FilterMatch: lipgloss.Color("6")}
+	plain := NewListDelegate(theme, ListDelegateOptions{}).(list.DefaultDelegate)
+	if _, ok := plain.Styles.NormalTitle.GetForeground().(lipgloss.NoColor); ok {
+		t.Error("expected the normal title to keep its color without a filter")
+	}
+	filtered := NewListDelegate(theme, ListDelegateOptions{Filtered: true}).(list.DefaultDelegate)
+	if _, ok := filtered.Styles.NormalTitle.GetForeground().(lipgloss.NoColor); !ok {
+		t.Error("expected the normal title to use the terminal foreground while filtered")
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// StatusLevel classifies a status message for coloring.
+type StatusLevel int
+
+// Status levels from least to most severe.
+const (
+	StatusInfo StatusLevel = iota
+	StatusSuccess
+	StatusWarning
+	StatusError
+)
+
+// String returns the lowercase level name.
+func (l StatusLevel) String() string {
+	switch l {
+	case StatusSuccess:
+		return "success"
+	case StatusWarning:
+		return "warning"
+	case StatusError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Color returns the theme color for level.
+func (t Theme) Color(level StatusLevel) lipgloss.TerminalColor {
+	switch level {
+	case StatusSuccess:
+		return t.Success
+	case StatusWarning:
+		return t.Warning
+	case StatusError:
+		return t.Error
+	default:
+		return t.Info
+	}
+}
+
+// StatusMessage renders a status line colored by level.
+func StatusMessage(theme Theme, level StatusLevel, message string) string {
+	return lipgloss.NewStyle().Foreground(theme.Color(level)).Render(message)
+}
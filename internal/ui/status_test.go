@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestThemeColor(t *testing.T) {
+	cfg := domain.DefaultConfig()
+	cfg.Success = "#00ff00"
+	cfg.Warning = "#ffff00"
+	cfg.Error = "#ff0000"
+	cfg.Info = "#0000ff"
+	theme := ThemeFromConfig(cfg)
+
+	tests := []struct {
+		level StatusLevel
+		want  lipgloss.TerminalColor
+	}{
+		{StatusInfo, lipgloss.Color("#0000ff")},
+		{StatusSuccess, lipgloss.Color("#00ff00")},
+		{StatusWarning, lipgloss.Color("#ffff00")},
+		{StatusError, lipgloss.Color("#ff0000")},
+	}
+	for _, tt := range tests {
+		if got := theme.Color(tt.level); got != tt.want {
+			t.Errorf("Color(%s) = %#v, want %#v", tt.level, got, tt.want)
+		}
+	}
+	if got := StatusMessage(theme, StatusError, "failed"); !strings.Contains(got, "failed") {
+		t.Errorf("StatusMessage() = %q, should contain the message", got)
+	}
+}
+
+func TestThemeSelection(t *testing.T) {
+	cfg := domain.DefaultConfig()
+	theme := ThemeFromConfig(cfg)
+	if got, want := theme.SelectionBackground, lipgloss.Color(""); got != want {
+		t.Errorf("default SelectionBackground = %#v, want no background", got)
+	}
+	cfg.SelectionBackground = "#313244"
+	theme = ThemeFromConfig(cfg)
+	if got, want := theme.SelectionBackground, lipgloss.Color("#313244"); got != want {
+		t.Errorf("SelectionBackground = %#v, want %#v", got, want)
+	}
+}
@@ -8,42 +8,63 @@ import (
 	"github.com/go-cli-template/internal/domain"
 )
 
-// Theme holds configurable colors for UI output.
+// Theme holds configurable colors for UI output. Colors configured as
+// light/dark pairs resolve to lipgloss.AdaptiveColor.
 type Theme struct {
-	Headings             lipgloss.Color
-	Primary              lipgloss.Color
-	Secondary            lipgloss.Color
-	Text                 lipgloss.Color
-	TextHighlight        lipgloss.Color
-	DescriptionHighlight lipgloss.Color
-	Tags                 lipgloss.Color
-	Flags                lipgloss.Color
-	Muted                lipgloss.Color
-	Border               lipgloss.Color
+	Headings             lipgloss.TerminalColor
+	Primary              lipgloss.TerminalColor
+	Secondary            lipgloss.TerminalColor
+	Text                 lipgloss.TerminalColor
+	TextHighlight        lipgloss.TerminalColor
+	DescriptionHighlight lipgloss.TerminalColor
+	Tags                 lipgloss.TerminalColor
+	Flags                lipgloss.TerminalColor
+	Muted                lipgloss.TerminalColor
+	Accent               lipgloss.TerminalColor
+	Border               lipgloss.TerminalColor
+	Success              lipgloss.TerminalColor
+	Warning              lipgloss.TerminalColor
+	Error                lipgloss.TerminalColor
+	Info                 lipgloss.TerminalColor
+	// SelectionBackground is the background of the selected list item and
+	// focused buttons. It is empty (no background) when not configured.
+	SelectionBackground lipgloss.TerminalColor
+	FilterMatch         lipgloss.TerminalColor
 }
 
 // ThemeFromConfig builds a theme with safe fallbacks.
 func ThemeFromConfig(cfg domain.Config) Theme {
 	return Theme{
-		Headings:             resolveColor(cfg.Headings, "15"),
+		Headings:             resolveColor(cfg.Headings, domain.AdaptiveColor("0", "15")),
 		Primary:              resolveColor(cfg.Primary, "02"),
 		Secondary:            resolveColor(cfg.Secondary, "06"),
-		Text:                 resolveColor(cfg.Text, "07"),
+		Text:                 resolveColor(cfg.Text, domain.AdaptiveColor("0", "07")),
 		TextHighlight:        resolveColor(resolveFallback(cfg.TextHighlight, cfg.Secondary), "06"),
 		DescriptionHighlight: resolveColor(resolveFallback(cfg.DescriptionHighlight, cfg.Secondary), "06"),
 		Tags:                 resolveColor(resolveFallback(cfg.Tags, cfg.Accent), "13"),
 		Flags:                resolveColor(cfg.Flags, "12"),
 		Muted:                resolveColor(cfg.Muted, "08"),
+		Accent:               resolveColor(cfg.Accent, "13"),
 		Border:               resolveColor(cfg.Border, "08"),
+		Success:              resolveColor(cfg.Success, "02"),
+		Warning:              resolveColor(cfg.Warning, "03"),
+		Error:                resolveColor(cfg.Error, "01"),
+		Info:                 resolveColor(cfg.Info, "04"),
+		SelectionBackground:  resolveColor(cfg.SelectionBackground, ""),
+		FilterMatch:          resolveColor(resolveFallback(cfg.FilterMatch, cfg.Secondary), "06"),
 	}
 }
 
-func resolveColor(value, fallback string) lipgloss.Color {
+func resolveColor(value, fallback string) lipgloss.TerminalColor {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
 		trimmed = fallback
 	}
-	return lipgloss.Color(trimmed)
+	light, dark := domain.SplitColor(trimmed)
+	if light == dark {
+		return lipgloss.Color(light)
+	}
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}
 }
 
 func resolveFallback(values ...string) string {
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastDurations is how long a toast stays on screen once shown. Problems
+// stay longer so they can be read before they disappear.
+var toastDurations = map[StatusLevel]time.Duration{
+	StatusInfo:    3 * time.Second,
+	StatusSuccess: 3 * time.Second,
+	StatusWarning: 6 * time.Second,
+	StatusError:   10 * time.Second,
+}
+
+// maxToastHistory bounds the number of toasts kept for the history view.
+const maxToastHistory = 100
+
+var toastIcons = map[StatusLevel]string{
+	StatusInfo:    "•",
+	StatusSuccess: "✓",
+	StatusWarning: "!",
+	StatusError:   "✗",
+}
+
+// Toast is a single notification.
+type Toast struct {
+	Level   StatusLevel
+	Message string
+	Time    time.Time
+	id      int
+}
+
+// toastExpiredMsg ends the toast with id once its duration has passed.
+type toastExpiredMsg struct {
+	id int
+}
+
+// Toasts queues notifications and shows them one at a time. Each toast is
+// dismissed by a tea.Tick after a duration that depends on its level, then
+// the next queued toast is shown. Every toast is also kept in a bounded
+// history.
+//
+// Example usage:
+//
+//	// In Init:
+//	return m.toasts.Init()
+//
+//	// To report something:
+//	return m, m.toasts.Push(ui.StatusSuccess, "Deleted %s", name)
+//
+//	// In Update:
+//	if cmd, ok := m.toasts.Update(msg); ok {
+//	    return m, cmd
+//	}
+type Toasts struct {
+	theme   Theme
+	queue   []Toast
+	history []Toast
+	nextID  int
+}
+
+// NewToasts returns an empty toast queue styled with theme.
+func NewToasts(theme Theme) Toasts {
+	return Toasts{theme: theme}
+}
+
+// SetTheme restyles the toasts, for example after a config reload.
+func (t *Toasts) SetTheme(theme Theme) {
+	t.theme = theme
+}
+
+// Init starts the timer of the toast on screen, if any. Use it for toasts
+// pushed before the program started.
+func (t Toasts) Init() tea.Cmd {
+	if len(t.queue) == 0 {
+		return nil
+	}
+	return t.expire(t.queue[0])
+}
+
+// Push queues a toast and returns the command that dismisses it when it
+// is shown right away.
+func (t *Toasts) Push(level StatusLevel, format string, args ...any) tea.Cmd {
+	t.nextID++
+	toast := Toast{
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+		Time:    time.Now(),
+		id:      t.nextID,
+	}
+	t.queue = append(t.queue, toast)
+	t.history = append(t.history, toast)
+	if len(t.history) > maxToastHistory {
+		t.history = t.history[len(t.history)-maxToastHistory:]
+	}
+	if len(t.queue) == 1 {
+		return t.expire(toast)
+	}
+	return nil
+}
+
+// Update dismisses the current toast when its timer fires and starts the
+// timer of the next one. It reports whether msg belonged to the toasts.
+func (t *Toasts) Update(msg tea.Msg) (tea.Cmd, bool) {
+	expired, ok := msg.(toastExpiredMsg)
+	if !ok {
+		return nil, false
+	}
+	// Timers of toasts that are no longer shown are ignored
+	if len(t.queue) == 0 || t.queue[0].id != expired.id {
+		return nil, true
+	}
+	t.queue = t.queue[1:]
+	if len(t.queue) == 0 {
+		return nil, true
+	}
+	return t.expire(t.queue[0]), true
+}
+
+// Current returns the toast on screen.
+func (t Toasts) Current() (Toast, bool) {
+	if len(t.queue) == 0 {
+		return Toast{}, false
+	}
+	return t.queue[0], true
+}
+
+// Pending returns the number of toasts waiting behind the current one.
+func (t Toasts) Pending() int {
+	return max(len(t.queue)-1, 0)
+}
+
+// History returns every toast pushed so far, oldest first.
+func (t Toasts) History() []Toast {
+	return t.history
+}
+
+// View renders the toast on screen, or an empty string when there is none.
+func (t Toasts) View() string {
+	toast, ok := t.Current()
+	if !ok {
+		return ""
+	}
+	view := t.render(toast)
+	if pending := t.Pending(); pending > 0 {
+		view += lipgloss.NewStyle().Foreground(t.theme.Muted).Render(fmt.Sprintf("  (+%d more)", pending))
+	}
+	return view
+}
+
+// HistoryView renders the most recent toasts, newest first, in at most
+// height lines including the title. A height of zero or less shows the
+// whole history.
+func (t Toasts) HistoryView(height int) string {
+	title := lipgloss.NewStyle().Foreground(t.theme.Headings).Bold(true).Render("Messages")
+	muted := lipgloss.NewStyle().Foreground(t.theme.Muted)
+	if len(t.history) == 0 {
+		return title + "\n\n" + muted.Render("No messages yet")
+	}
+
+	var lines []string
+	for i := len(t.history) - 1; i >= 0; i-- {
+		toast := t.history[i]
+		stamp := muted.Render(toast.Time.Format("15:04:05"))
+		for j, line := range strings.Split(t.render(toast), "\n") {
+			if j == 0 {
+				lines = append(lines, stamp+" "+line)
+				continue
+			}
+			lines = append(lines, strings.Repeat(" ", lipgloss.Width(stamp)+1)+line)
+		}
+	}
+	// Leave room for the title
+	if height > 0 && len(lines) > height-2 {
+		lines = lines[:max(height-2, 1)]
+	}
+	return title + "\n\n" + strings.Join(lines, "\n")
+}
+
+func (t Toasts) render(toast Toast) string {
+	style := lipgloss.NewStyle().Foreground(t.theme.Color(toast.Level))
+	return style.Render(toastIcons[toast.Level] + " " + toast.Message)
+}
+
+func (t Toasts) expire(toast Toast) tea.Cmd {
+	return tea.Tick(toastDurations[toast.Level], func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: toast.id}
+	})
+}
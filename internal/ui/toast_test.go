@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-cli-template/internal/domain"
+)
+
+func TestToasts(t *testing.T) {
+	theme := ThemeFromConfig(domain.DefaultConfig())
+
+	t.Run("shows queued toasts one at a time", func(t *testing.T) {
+		toasts := NewToasts(theme)
+		if cmd := toasts.Push(StatusSuccess, "Deleted %s", "a.txt"); cmd == nil {
+			t.Fatal("expected the first toast to start its timer")
+		}
+		if cmd := toasts.Push(StatusError, "failed"); cmd != nil {
+			t.Fatal("expected a queued toast to wait for the current one")
+		}
+
+		current, ok := toasts.Current()
+		if !ok || current.Message != "Deleted a.txt" || current.Level != StatusSuccess {
+			t.Fatalf("unexpected current toast %+v", current)
+		}
+		view := toasts.View()
+		if !strings.Contains(view, "Deleted a.txt") || !strings.Contains(view, "+1 more") {
+			t.Errorf("View() = %q, want current toast and pending count", view)
+		}
+
+		cmd, handled := toasts.Update(toastExpiredMsg{id: current.id})
+		if !handled || cmd == nil {
+			t.Fatal("expected expiry to start the next toast's timer")
+		}
+		if next, _ := toasts.Current(); next.Message != "failed" {
+			t.Errorf("expected the queued toast next, got %+v", next)
+		}
+		if toasts.Pending() != 0 {
+			t.Errorf("Pending() = %d, want 0", toasts.Pending())
+		}
+	})
+
+	t.Run("ignores stale timers", func(t *testing.T) {
+		toasts := NewToasts(theme)
+		toasts.Push(StatusInfo, "first")
+		first, _ := toasts.Current()
+		toasts.Update(toastExpiredMsg{id: first.id})
+		toasts.Push(StatusInfo, "second")
+
+		if _, handled := toasts.Update(toastExpiredMsg{id: first.id}); !handled {
+			t.Error("expected expiry messages to be handled")
+		}
+		if current, ok := toasts.Current(); !ok || current.Message != "second" {
+			t.Errorf("stale timer dismissed the current toast, got %+v", current)
+		}
+	})
+
+	t.Run("ignores other messages", func(t *testing.T) {
+		toasts := NewToasts(theme)
+		if _, handled := toasts.Update("tick"); handled {
+			t.Error("expected unrelated messages to be ignored")
+		}
+		if toasts.View() != "" {
+			t.Error("expected an empty view without toasts")
+		}
+		if toasts.Init() != nil {
+			t.Error("expected no timer without toasts")
+		}
+	})
+
+	t.Run("keeps a bounded history", func(t *testing.T) {
+		toasts := NewToasts(theme)
+		for i := 0; i < maxToastHistory+5; i++ {
+			toasts.Push(StatusInfo, "message %d", i)
+		}
+		history := toasts.History()
+		if len(history) != maxToastHistory {
+			t.Fatalf("len(History()) = %d, want %d", len(history), maxToastHistory)
+		}
+		if history[0].Message != "message 5" {
+			t.Errorf("expected the oldest entries to be dropped, got %q", history[0].Message)
+		}
+	})
+
+	t.Run("renders history newest first", func(t *testing.T) {
+		toasts := NewToasts(theme)
+		if view := toasts.HistoryView(0); !strings.Contains(view, "No messages yet") {
+			t.Errorf("HistoryView() = %q, want empty notice", view)
+		}
+		toasts.Push(StatusInfo, "older")
+		toasts.Push(StatusWarning, "newer")
+
+		view := toasts.HistoryView(0)
+		if strings.Index(view, "newer") > strings.Index(view, "older") {
+			t.Errorf("expected newest message first, got:\n%s", view)
+		}
+		if view := toasts.HistoryView(3); strings.Contains(view, "older") {
+			t.Errorf("expected height to limit the history, got:\n%s", view)
+		}
+	})
+}
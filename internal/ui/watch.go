@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultWatchInterval is how often a FileWatcher polls its files.
+const DefaultWatchInterval = time.Second
+
+// FilesChangedMsg is sent when any watched file is created, modified or removed.
+type FilesChangedMsg struct {
+	Watcher FileWatcher
+}
+
+// filesUnchangedMsg keeps the poll loop running when nothing changed.
+type filesUnchangedMsg struct {
+	watcher FileWatcher
+}
+
+// FileWatcher polls a set of files for changes using tea.Tick. Polling is
+// used instead of filesystem notifications so editors that replace files on
+// save are handled the same as editors that write in place.
+//
+// Example usage:
+//
+//	// In Init:
+//	return m.watcher.Watch()
+//
+//	// In Update:
+//	case ui.FilesChangedMsg:
+//	    m.watcher = msg.Watcher
+//	    return m, tea.Batch(reload(), m.watcher.Watch())
+//
+//	// After a reload that may watch other files:
+//	    m.watcher = m.watcher.WithPaths(paths)
+//	default:
+//	    if cmd, ok := m.watcher.Continue(msg); ok {
+//	        return m, cmd
+//	    }
+type FileWatcher struct {
+	paths    []string
+	interval time.Duration
+	stamps   map[string]fileStamp
+}
+
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// NewFileWatcher records the current state of paths. Missing files are
+// watched too, so creating one counts as a change.
+func NewFileWatcher(paths []string, interval time.Duration) FileWatcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	return FileWatcher{
+		paths:    paths,
+		interval: interval,
+		stamps:   stampFiles(paths),
+	}
+}
+
+// Paths returns the watched file paths.
+func (w FileWatcher) Paths() []string {
+	return w.paths
+}
+
+// WithPaths returns a watcher for paths that keeps the stamps already taken
+// for paths it watched before, so changes made since then are still
+// reported. Only new paths are stamped now.
+func (w FileWatcher) WithPaths(paths []string) FileWatcher {
+	stamps := make(map[string]fileStamp, len(paths))
+	var added []string
+	for _, path := range paths {
+		if stamp, ok := w.stamps[path]; ok {
+			stamps[path] = stamp
+		} else {
+			added = append(added, path)
+		}
+	}
+	for path, stamp := range stampFiles(added) {
+		stamps[path] = stamp
+	}
+	return FileWatcher{paths: paths, interval: w.interval, stamps: stamps}
+}
+
+// Watch waits one interval, then reports whether any file changed.
+func (w FileWatcher) Watch() tea.Cmd {
+	if len(w.paths) == 0 {
+		return nil
+	}
+	return tea.Tick(w.interval, func(time.Time) tea.Msg {
+		stamps := stampFiles(w.paths)
+		next := FileWatcher{paths: w.paths, interval: w.interval, stamps: stamps}
+		if stampsChanged(w.stamps, stamps) {
+			return FilesChangedMsg{Watcher: next}
+		}
+		return filesUnchangedMsg{watcher: next}
+	})
+}
+
+// Continue schedules the next poll when msg is an internal watcher tick.
+func (w FileWatcher) Continue(msg tea.Msg) (tea.Cmd, bool) {
+	if tick, ok := msg.(filesUnchangedMsg); ok {
+		return tick.watcher.Watch(), true
+	}
+	return nil, false
+}
+
+func stampFiles(paths []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			stamps[path] = fileStamp{}
+			continue
+		}
+		stamps[path] = fileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+	}
+	return stamps
+}
+
+func stampsChanged(before, after map[string]fileStamp) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for path, stamp := range after {
+		previous, ok := before[path]
+		if !ok || previous.exists != stamp.exists || previous.size != stamp.size || !previous.modTime.Equal(stamp.modTime) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileWatcher(t *testing.T) {
+	t.Run("reports no change for untouched files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte("editor = \"vim\"\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+
+		watcher := NewFileWatcher([]string{path}, time.Millisecond)
+		msg := watcher.Watch()()
+		if _, changed := msg.(FilesChangedMsg); changed {
+			t.Fatal("expected no change")
+		}
+		if _, ok := watcher.Continue(msg); !ok {
+			t.Error("expected Continue to handle the unchanged tick")
+		}
+	})
+
+	t.Run("reports modified files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte("editor = \"vim\"\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+
+		watcher := NewFileWatcher([]string{path}, time.Millisecond)
+		if err := os.WriteFile(path, []byte("editor = \"emacs\"\n"), 0o644); err != nil {
+			t.Fatalf("rewrite: %v", err)
+		}
+		if _, changed := watcher.Watch()().(FilesChangedMsg); !changed {
+			t.Fatal("expected change after rewrite")
+		}
+	})
+
+	t.Run("reports created files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+
+		watcher := NewFileWatcher([]string{path}, time.Millisecond)
+		if err := os.WriteFile(path, []byte(""), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, changed := watcher.Watch()().(FilesChangedMsg); !changed {
+			t.Fatal("expected change after creation")
+		}
+	})
+
+	t.Run("keeps stamps when paths change", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		if err := os.WriteFile(path, []byte("editor = \"vim\"\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+
+		watcher := NewFileWatcher([]string{path}, time.Millisecond)
+		if err := os.WriteFile(path, []byte("editor = \"emacs\"\n"), 0o644); err != nil {
+			t.Fatalf("rewrite: %v", err)
+		}
+		watcher = watcher.WithPaths([]string{path, filepath.Join(dir, "extra.toml")})
+		if _, changed := watcher.Watch()().(FilesChangedMsg); !changed {
+			t.Fatal("expected the earlier rewrite to be reported")
+		}
+	})
+
+	t.Run("does not watch without paths", func(t *testing.T) {
+		watcher := NewFileWatcher(nil, time.Millisecond)
+		if cmd := watcher.Watch(); cmd != nil {
+			t.Error("expected nil command without paths")
+		}
+	})
+}
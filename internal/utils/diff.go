@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffOp is a single line-level edit.
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// UnifiedDiff returns a unified diff turning before into after, labelled
+// with the given file names. It returns an empty string when the inputs
+// have the same lines; a missing final newline is not a difference.
+func UnifiedDiff(beforeName, afterName, before, after string) string {
+	if before == after {
+		return ""
+	}
+	hunks := diffHunks(diffLines(splitLines(before), splitLines(after)))
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", beforeName, afterName)
+	for _, hunk := range hunks {
+		builder.WriteString(hunk)
+	}
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines computes a minimal edit script using the longest common
+// subsequence of the two inputs. Lines the inputs start and end with are
+// matched first, so the table only covers the part that changed.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// diffMiddle computes the edit script for the changed part of the inputs.
+func diffMiddle(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// diffHunks groups ops into hunks with diffContext lines of context.
+func diffHunks(ops []diffOp) []string {
+	var hunks []string
+	for start := 0; start < len(ops); {
+		// Find the next change.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		// Extend the hunk while changes are within two context windows.
+		last := first
+		for next := first; next < len(ops); next++ {
+			if ops[next].kind != ' ' {
+				if next-last > 2*diffContext {
+					break
+				}
+				last = next
+			}
+		}
+		from := max(first-diffContext, 0)
+		to := min(last+diffContext+1, len(ops))
+
+		// Line numbers are 1-based positions in each input.
+		beforeLine, afterLine := 1, 1
+		for _, op := range ops[:from] {
+			if op.kind != '+' {
+				beforeLine++
+			}
+			if op.kind != '-' {
+				afterLine++
+			}
+		}
+		beforeCount, afterCount := 0, 0
+		var body strings.Builder
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				beforeCount++
+			}
+			if op.kind != '-' {
+				afterCount++
+			}
+			body.WriteByte(op.kind)
+			body.WriteString(op.line)
+			body.WriteByte('\n')
+		}
+		if beforeCount == 0 {
+			beforeLine--
+		}
+		if afterCount == 0 {
+			afterLine--
+		}
+		hunks = append(hunks, fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", beforeLine, beforeCount, afterLine, afterCount, body.String()))
+		start = to
+	}
+	return hunks
+}
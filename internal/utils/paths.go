@@ -3,6 +3,7 @@ package utils
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-cli-template/internal/package"
 )
@@ -22,6 +23,32 @@ func XDGCacheHome() string {
 	return xdgHome("XDG_CACHE_HOME", ".cache")
 }
 
+// XDGConfigDirs returns the system config directories in order of preference.
+func XDGConfigDirs() []string {
+	value := os.Getenv("XDG_CONFIG_DIRS")
+	if strings.TrimSpace(value) == "" {
+		value = "/etc/xdg"
+	}
+	var dirs []string
+	for _, dir := range filepath.SplitList(value) {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// ConfigPathsSystem returns system config file paths from lowest to highest
+// precedence, so the most preferred XDG_CONFIG_DIRS entry is applied last.
+func ConfigPathsSystem() []string {
+	dirs := XDGConfigDirs()
+	paths := make([]string, 0, len(dirs))
+	for i := len(dirs) - 1; i >= 0; i-- {
+		paths = append(paths, filepath.Join(dirs[i], pkg.Name(), "config.toml"))
+	}
+	return paths
+}
+
 // ConfigPathGlobal returns the default global config file path.
 func ConfigPathGlobal() string {
 	return filepath.Join(XDGConfigHome(), pkg.Name(), "config.toml")
@@ -32,6 +59,68 @@ func ConfigPathLocal(cwd string) string {
 	return filepath.Join(cwd, "."+pkg.Name(), "config.toml")
 }
 
+// Local config search boundaries.
+const (
+	// BoundaryGit stops at the nearest git root or $HOME, whichever comes first.
+	BoundaryGit = "git"
+	// BoundaryHome stops at $HOME.
+	BoundaryHome = "home"
+	// BoundaryRoot walks up to the filesystem root.
+	BoundaryRoot = "root"
+	// BoundaryNone only checks the given directory.
+	BoundaryNone = "none"
+)
+
+// LocalConfigDirs returns the directories searched for local config, from
+// outermost to innermost. The search starts at cwd and includes the boundary
+// directory itself.
+func LocalConfigDirs(cwd, boundary string) []string {
+	home, _ := os.UserHomeDir()
+	var dirs []string
+	for dir := filepath.Clean(cwd); ; {
+		dirs = append(dirs, dir)
+		if boundary == BoundaryNone {
+			break
+		}
+		if boundary != BoundaryRoot && home != "" && dir == home {
+			break
+		}
+		if (boundary == BoundaryGit || boundary == "") && isGitRoot(dir) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	for i, j := 0, len(dirs)-1; i < j; i, j = i+1, j-1 {
+		dirs[i], dirs[j] = dirs[j], dirs[i]
+	}
+	return dirs
+}
+
+// ProjectRoot returns the nearest git root above cwd, or cwd when there is none.
+func ProjectRoot(cwd string) string {
+	for dir := filepath.Clean(cwd); ; {
+		if isGitRoot(dir) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return filepath.Clean(cwd)
+		}
+		dir = parent
+	}
+}
+
+// isGitRoot reports whether dir contains a .git directory or file (worktrees
+// and submodules use a .git file).
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
 func xdgHome(envKey, fallbackSuffix string) string {
 	if value := os.Getenv(envKey); value != "" {
 		return value
@@ -3,9 +3,17 @@ package utils
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
+// isWithin reports whether path is dir or inside it. Unlike a string
+// prefix check, "/ab/x" is not within "/a".
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func TestXDGConfigHome(t *testing.T) {
 	t.Run("uses XDG_CONFIG_HOME when set", func(t *testing.T) {
 		expected := "/custom/config"
@@ -90,7 +98,7 @@ func TestConfigPathGlobal(t *testing.T) {
 	got := ConfigPathGlobal()
 	
 	// Verify it uses the XDG_CONFIG_HOME
-	if !filepath.HasPrefix(got, "/test/config") {
+	if !isWithin(got, "/test/config") {
 		t.Errorf("ConfigPathGlobal() = %q, should start with /test/config", got)
 	}
 	
@@ -105,7 +113,7 @@ func TestConfigPathLocal(t *testing.T) {
 	got := ConfigPathLocal(cwd)
 	
 	// Verify it starts with the cwd
-	if !filepath.HasPrefix(got, cwd) {
+	if !isWithin(got, cwd) {
 		t.Errorf("ConfigPathLocal(%q) = %q, should start with %q", cwd, got, cwd)
 	}
 	
@@ -114,3 +122,96 @@ func TestConfigPathLocal(t *testing.T) {
 		t.Errorf("ConfigPathLocal(%q) = %q, should end with config.toml", cwd, got)
 	}
 }
+
+func TestXDGConfigDirs(t *testing.T) {
+	t.Run("defaults to /etc/xdg", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_DIRS", "")
+		got := XDGConfigDirs()
+		if len(got) != 1 || got[0] != "/etc/xdg" {
+			t.Errorf("XDGConfigDirs() = %v, want [/etc/xdg]", got)
+		}
+	})
+
+	t.Run("splits XDG_CONFIG_DIRS", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_DIRS", "/a"+string(os.PathListSeparator)+"/b")
+		got := XDGConfigDirs()
+		if len(got) != 2 || got[0] != "/a" || got[1] != "/b" {
+			t.Errorf("XDGConfigDirs() = %v, want [/a /b]", got)
+		}
+	})
+}
+
+func TestConfigPathsSystem(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIRS", "/a"+string(os.PathListSeparator)+"/b")
+	got := ConfigPathsSystem()
+	if len(got) != 2 {
+		t.Fatalf("ConfigPathsSystem() = %v, want 2 paths", got)
+	}
+	// Least preferred directory comes first so the preferred one wins
+	if !isWithin(got[0], "/b") || !isWithin(got[1], "/a") {
+		t.Errorf("ConfigPathsSystem() = %v, want /b before /a", got)
+	}
+}
+
+func TestLocalConfigDirs(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	repo := filepath.Join(root, "repo")
+	pkgDir := filepath.Join(repo, "pkg")
+	nested := filepath.Join(pkgDir, "sub")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir nested: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		boundary string
+		want     []string
+	}{
+		{"git stops at repository root", BoundaryGit, []string{repo, pkgDir, nested}},
+		{"empty boundary behaves like git", "", []string{repo, pkgDir, nested}},
+		{"home continues past repository root", BoundaryHome, []string{root, repo, pkgDir, nested}},
+		{"none only checks cwd", BoundaryNone, []string{nested}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LocalConfigDirs(nested, tt.boundary)
+			if len(got) != len(tt.want) {
+				t.Fatalf("LocalConfigDirs() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("LocalConfigDirs()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "a", "b")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir nested: %v", err)
+	}
+
+	if got := ProjectRoot(nested); got != repo {
+		t.Errorf("ProjectRoot(%q) = %q, want %q", nested, got, repo)
+	}
+
+	outside := filepath.Join(root, "outside")
+	if err := os.MkdirAll(outside, 0o755); err != nil {
+		t.Fatalf("mkdir outside: %v", err)
+	}
+	if got := ProjectRoot(outside); got != outside {
+		t.Errorf("ProjectRoot(%q) = %q, want %q", outside, got, outside)
+	}
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// SplitWords splits a command line into words the way a POSIX shell does,
+// without expanding variables, globs or command substitutions. Single
+// quotes keep their contents literally; inside double quotes a backslash
+// only escapes $, `, ", \ and newline; elsewhere a backslash escapes the
+// next character. An unterminated quote or trailing backslash is an error.
+func SplitWords(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			// Inside double quotes, a backslash before other characters is literal
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", r) {
+				word.WriteRune('\\')
+			}
+			if r != '\n' {
+				word.WriteRune(r)
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated " + string(quote) + " quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{"empty", "   ", nil},
+		{"plain words", "code  --wait\t-n", []string{"code", "--wait", "-n"}},
+		{"double quotes", `"/Applications/Visual Studio Code.app/bin/code" --wait`, []string{"/Applications/Visual Studio Code.app/bin/code", "--wait"}},
+		{"single quotes", `vim '+set ft=go' 'it''s'`, []string{"vim", "+set ft=go", "its"}},
+		{"escaped space", `/opt/My\ Editor/bin/edit -w`, []string{"/opt/My Editor/bin/edit", "-w"}},
+		{"escapes in double quotes", `"a \"b\" \$c \d"`, []string{`a "b" $c \d`}},
+		{"literal backslash in single quotes", `'a\b'`, []string{`a\b`}},
+		{"empty quoted word", `cmd "" ''`, []string{"cmd", "", ""}},
+		{"adjacent quoting", `--opt="a b"c`, []string{"--opt=a bc"}},
+		{"no expansion", `echo $HOME ~ *`, []string{"echo", "$HOME", "~", "*"}},
+		{"line continuation", "a\\\nb", []string{"ab"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitWords(tt.line)
+			if err != nil {
+				t.Fatalf("SplitWords(%q): %v", tt.line, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitWords(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+
+	for _, line := range []string{`"open`, `'open`, `trailing\`} {
+		if _, err := SplitWords(line); err == nil {
+			t.Errorf("SplitWords(%q) should fail", line)
+		}
+	}
+}
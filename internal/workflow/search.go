@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SearchOptions controls a content search.
+type SearchOptions struct {
+	// IgnoreCase matches the pattern case-insensitively.
+	IgnoreCase bool
+	// Hidden includes files and directories whose names start with a dot.
+	Hidden bool
+	// MaxResults stops the search after this many matches; zero means no
+	// limit.
+	MaxResults int
+}
+
+// SearchMatch is a line matching a content search. Line and Column are
+// 1-based; Column is counted in bytes.
+type SearchMatch struct {
+	Path   string
+	Line   int
+	Column int
+	Text   string
+}
+
+// ErrSearchLimit is returned with the matches found so far when
+// SearchOptions.MaxResults is reached.
+var ErrSearchLimit = errors.New("search result limit reached")
+
+// skippedDirs are never searched, even with SearchOptions.Hidden.
+var skippedDirs = map[string]bool{".git": true, ".hg": true, ".svn": true, "node_modules": true}
+
+// Search reports every line under roots that matches the regular
+// expression pattern, in walk order. Roots may be files or directories.
+// Binary files and version control directories are skipped. Files and
+// directories that cannot be read are skipped too and returned as
+// warnings; only a root that does not exist is an error.
+func Search(pattern string, roots []string, opts SearchOptions) ([]SearchMatch, []string, error) {
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	var matches []SearchMatch
+	var warnings []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if entry == nil && path == root {
+					return err
+				}
+				warnings = append(warnings, fmt.Sprintf("skipped %s: %v", path, unwrapPathError(err)))
+				if entry != nil && entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			hidden := path != root && strings.HasPrefix(entry.Name(), ".")
+			if entry.IsDir() {
+				if path != root && (skippedDirs[entry.Name()] || (hidden && !opts.Hidden)) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !entry.Type().IsRegular() || (hidden && !opts.Hidden) {
+				return nil
+			}
+			found, err := searchFile(re, path, opts.MaxResults-len(matches))
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("skipped %s: %v", path, unwrapPathError(err)))
+				return nil
+			}
+			matches = append(matches, found...)
+			if opts.MaxResults > 0 && len(matches) >= opts.MaxResults {
+				return ErrSearchLimit
+			}
+			return nil
+		})
+		if err != nil {
+			return matches, warnings, err
+		}
+	}
+	return matches, warnings, nil
+}
+
+// unwrapPathError drops the path from err, which the warnings already name.
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
+
+// searchFile returns up to limit matches in path, or all of them when
+// limit is zero or less. Binary files yield no matches.
+func searchFile(re *regexp.Regexp, path string, limit int) ([]SearchMatch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil, nil
+	}
+
+	var matches []SearchMatch
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		loc := re.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		matches = append(matches, SearchMatch{
+			Path:   path,
+			Line:   line,
+			Column: loc[0] + 1,
+			Text:   strings.TrimRight(text, "\r"),
+		})
+		if limit > 0 && len(matches) >= limit {
+			break
+		}
+	}
+	return matches, scanner.Err()
+}
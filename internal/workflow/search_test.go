@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSearchFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return root
+}
+
+func TestSearch(t *testing.T) {
+	root := writeSearchFiles(t, map[string]string{
+		"a.go":         "package a\n// TODO: first\nfunc A() {} // todo later\n",
+		"sub/b.go":     "package b\n\tTODO second\n",
+		".hidden/c.go": "TODO hidden\n",
+		".git/config":  "TODO git\n",
+		"image.bin":    "TODO\x00binary",
+	})
+
+	matches, _, err := Search("TODO", []string{root}, SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	want := []SearchMatch{
+		{Path: filepath.Join(root, "a.go"), Line: 2, Column: 4, Text: "// TODO: first"},
+		{Path: filepath.Join(root, "sub", "b.go"), Line: 2, Column: 2, Text: "\tTODO second"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("Search() = %+v, want %+v", matches, want)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+
+	t.Run("ignore case", func(t *testing.T) {
+		matches, _, err := Search("todo", []string{root}, SearchOptions{IgnoreCase: true})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if len(matches) != 3 {
+			t.Errorf("expected 3 matches, got %+v", matches)
+		}
+	})
+
+	t.Run("hidden files", func(t *testing.T) {
+		matches, _, err := Search("TODO", []string{root}, SearchOptions{Hidden: true})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if len(matches) != 3 {
+			t.Errorf("expected the hidden file but not .git, got %+v", matches)
+		}
+	})
+
+	t.Run("result limit", func(t *testing.T) {
+		matches, _, err := Search("TODO", []string{root}, SearchOptions{MaxResults: 1})
+		if !errors.Is(err, ErrSearchLimit) {
+			t.Errorf("expected ErrSearchLimit, got %v", err)
+		}
+		if len(matches) != 1 {
+			t.Errorf("expected 1 match, got %+v", matches)
+		}
+	})
+
+	t.Run("unreadable directory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("permissions are not enforced for root")
+		}
+		locked := filepath.Join(root, "locked")
+		if err := os.Mkdir(locked, 0o000); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		t.Cleanup(func() { os.Chmod(locked, 0o755) })
+
+		matches, warnings, err := Search("TODO", []string{root}, SearchOptions{})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if len(matches) != 2 {
+			t.Errorf("expected the readable matches, got %+v", matches)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], locked) {
+			t.Errorf("expected a warning for %s, got %v", locked, warnings)
+		}
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		if _, _, err := Search("TODO", []string{filepath.Join(root, "missing")}, SearchOptions{}); err == nil {
+			t.Error("expected an error for a missing root")
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		if _, _, err := Search("(", []string{root}, SearchOptions{}); err == nil {
+			t.Error("expected an error for an invalid pattern")
+		}
+	})
+}